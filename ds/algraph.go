@@ -32,6 +32,27 @@ func (g *AlGraph) GetMap() map[string][]string {
 	return g.m
 }
 
+// ToGraph returns a directed Graph with the same vertices and edges as the AlGraph. Every edge
+// weighs 1, except that repeated edges between the same vertices are merged into a single edge whose
+// weight is the number of times it was repeated.
+func (g *AlGraph) ToGraph() *Graph[string, int] {
+	graph := NewDirectedGraph[string, int]()
+	for s, neighbors := range g.m {
+		if !graph.HasVertex(s) {
+			graph.addVertex(s)
+		}
+		for _, d := range neighbors {
+			if edge, err := graph.Edge(s, d); err == nil {
+				_ = graph.SetWeight(s, d, edge.Weight+1)
+			} else {
+				_ = graph.AddEdge(s, d, 1)
+			}
+		}
+	}
+
+	return graph
+}
+
 func (g *AlGraph) NumPaths(start, end string) int {
 	visited := make(map[string]struct{})
 	return g.numPaths(start, end, &visited)
@@ -54,3 +54,42 @@ func TestAlGraphNumPaths(t *testing.T) {
 		assert.Equal(t, g.NumPaths("A", "E"), 2)
 	})
 }
+
+func TestAlGraphToGraph(t *testing.T) {
+	t.Run("should convert to a directed graph with unit weights", func(t *testing.T) {
+		al, err := ds.NewAlGraph([][]string{
+			{"A", "B"},
+			{"B", "C"},
+			{"B", "E"},
+			{"C", "E"},
+			{"E", "D"},
+		})
+		assert.Nil(t, err)
+
+		g := al.ToGraph()
+		assert.True(t, g.Directed())
+		assert.Equal(t, 5, g.NumVertices())
+		assert.Equal(t, 5, g.NumEdges())
+		assert.True(t, g.HasVertex("D"), "should add vertices with no outgoing edges")
+		assert.True(t, g.HasEdge("B", "E"))
+		assert.False(t, g.HasEdge("E", "B"))
+
+		edge, err := g.Edge("C", "E")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, edge.Weight)
+	})
+
+	t.Run("should merge repeated edges into a heavier edge", func(t *testing.T) {
+		al, err := ds.NewAlGraph([][]string{
+			{"A", "B"},
+			{"A", "B"},
+			{"A", "C"},
+		})
+		assert.Nil(t, err)
+
+		g := al.ToGraph()
+		assert.Equal(t, 2, g.NumEdges())
+		edge, _ := g.Edge("A", "B")
+		assert.Equal(t, 2, edge.Weight)
+	})
+}
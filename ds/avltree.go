@@ -2,6 +2,7 @@ package ds
 
 import (
 	"errors"
+	"iter"
 
 	"golang.org/x/exp/constraints"
 )
@@ -12,6 +13,11 @@ type AVLTreeNode[T constraints.Ordered] struct {
 	Left   *AVLTreeNode[T]
 	Right  *AVLTreeNode[T]
 	height int
+	// size is the number of nodes in the subtree rooted at this node (including itself)
+	size int
+	// value is an optional payload carried along with the element, which lets OrderedMap keep each
+	// value on the node holding its key
+	value any
 }
 
 // NewAVLTreeNode returns a pointer to a new AVL Tree Node.
@@ -21,6 +27,7 @@ func NewAVLTreeNode[T constraints.Ordered](elem T) *AVLTreeNode[T] {
 		Left:   nil,
 		Right:  nil,
 		height: 0,
+		size:   1,
 	}
 }
 
@@ -30,6 +37,11 @@ func (n AVLTreeNode[T]) GetHeight() int {
 	return max(leftHeight, rightHeight) + 1
 }
 
+// GetSize returns the number of nodes in the subtree rooted at the specified node.
+func (n AVLTreeNode[T]) GetSize() int {
+	return avlSubtreeSize(n.Left) + avlSubtreeSize(n.Right) + 1
+}
+
 // GetBalanceFactor returns the balance factor of a particular node, telling us if it is balanced,
 // left-heavy, or right-heavy.
 func (n AVLTreeNode[T]) GetBalanceFactor() int {
@@ -37,6 +49,21 @@ func (n AVLTreeNode[T]) GetBalanceFactor() int {
 	return leftHeight - rightHeight
 }
 
+// recalculate updates the cached height and subtree size of the node from its children.
+func (n *AVLTreeNode[T]) recalculate() {
+	n.height = n.GetHeight()
+	n.size = n.GetSize()
+}
+
+// avlSubtreeSize returns the number of nodes in the subtree rooted at the given node, which may be
+// nil.
+func avlSubtreeSize[T constraints.Ordered](n *AVLTreeNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
 func (n AVLTreeNode[T]) getChildrenHeights() (int, int) {
 	leftHeight := -1
 	rightHeight := -1
@@ -54,6 +81,8 @@ func (n AVLTreeNode[T]) getChildrenHeights() (int, int) {
 type AVLTree[T constraints.Ordered] struct {
 	Root *AVLTreeNode[T]
 	size int
+	// rotations counts the rotations performed to keep the tree balanced
+	rotations int
 }
 
 // NewAVLTree returns a pointer to a new AVL Tree.
@@ -78,7 +107,7 @@ func (t AVLTree[T]) Contains(elem T) bool {
 
 // Insert adds an element into the tree and maintains the binary search and AVL balance properties
 func (t *AVLTree[T]) Insert(elem T) error {
-	if node, err := t.insert(t.Root, elem); err != nil {
+	if node, err := t.insert(t.Root, elem, nil); err != nil {
 		return err
 	} else {
 		t.Root = node
@@ -98,9 +127,181 @@ func (t *AVLTree[T]) Remove(elem T) error {
 	}
 }
 
+// Min returns the smallest element in the tree; if the tree is empty an error is returned.
+func (t AVLTree[T]) Min() (T, error) {
+	if t.Root == nil {
+		var empty T
+		return empty, errors.New("tree is empty")
+	}
+
+	return t.findMin(t.Root).Elem, nil
+}
+
+// Max returns the largest element in the tree; if the tree is empty an error is returned.
+func (t AVLTree[T]) Max() (T, error) {
+	if t.Root == nil {
+		var empty T
+		return empty, errors.New("tree is empty")
+	}
+
+	return t.findMax(t.Root).Elem, nil
+}
+
+// Floor returns the largest element in the tree that is less than or equal to the given element; if
+// there is no such element an error is returned.
+func (t AVLTree[T]) Floor(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, true, true)
+}
+
+// Ceiling returns the smallest element in the tree that is greater than or equal to the given
+// element; if there is no such element an error is returned.
+func (t AVLTree[T]) Ceiling(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, false, true)
+}
+
+// Lower returns the largest element in the tree that is strictly less than the given element; if
+// there is no such element an error is returned.
+func (t AVLTree[T]) Lower(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, true, false)
+}
+
+// Higher returns the smallest element in the tree that is strictly greater than the given element;
+// if there is no such element an error is returned.
+func (t AVLTree[T]) Higher(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, false, false)
+}
+
+// Select returns the element with the given 0-based rank, i.e. the k-th smallest element in the
+// tree (Select(0) returns the minimum). If k is out of range an error is returned. Select runs in
+// O(log n) time.
+func (t AVLTree[T]) Select(k int) (T, error) {
+	if k < 0 || k >= t.size {
+		var empty T
+		return empty, errors.New("rank out of range")
+	}
+
+	node := t.Root
+	for {
+		leftSize := avlSubtreeSize(node.Left)
+		if k < leftSize {
+			node = node.Left
+		} else if k > leftSize {
+			// skip over the left subtree and the current node
+			k -= leftSize + 1
+			node = node.Right
+		} else {
+			return node.Elem, nil
+		}
+	}
+}
+
+// Rank returns the number of elements in the tree that are strictly less than the given element,
+// which need not be in the tree. When the element is in the tree this is its 0-based position in
+// sorted order. Rank runs in O(log n) time.
+func (t AVLTree[T]) Rank(elem T) int {
+	return t.rank(elem, false)
+}
+
+// CountRange returns the number of elements in the tree between lo and hi (inclusive) in O(log n)
+// time.
+func (t AVLTree[T]) CountRange(lo, hi T) int {
+	if hi < lo {
+		return 0
+	}
+
+	return t.rank(hi, true) - t.rank(lo, false)
+}
+
+// All returns an iterator over every element in the tree in ascending order. The tree must not be
+// modified during iteration.
+func (t AVLTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.rangeNodes(t.Root, nil, nil, func(n *AVLTreeNode[T]) bool { return yield(n.Elem) })
+	}
+}
+
+// Range returns an iterator over the elements in the tree between lo and hi (inclusive) in
+// ascending order. Subtrees that fall entirely outside of the range are never visited. The tree
+// must not be modified during iteration.
+func (t AVLTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.rangeNodes(t.Root, &lo, &hi, func(n *AVLTreeNode[T]) bool { return yield(n.Elem) })
+	}
+}
+
 /* Private helper functions
 ------------------------------------------------------------------------------------------------- */
 
+// elem, left and right let treeNeighbor search an AVLTree the same way as a BST.
+func (n *AVLTreeNode[T]) elem() T {
+	return n.Elem
+}
+
+func (n *AVLTreeNode[T]) left() *AVLTreeNode[T] {
+	return n.Left
+}
+
+func (n *AVLTreeNode[T]) right() *AVLTreeNode[T] {
+	return n.Right
+}
+
+// rank returns the number of elements less than the given element (or less than or equal to it if
+// inclusive is set).
+func (t AVLTree[T]) rank(elem T, inclusive bool) int {
+	count := 0
+	node := t.Root
+
+	for node != nil {
+		if node.Elem < elem || (inclusive && node.Elem == elem) {
+			// the node and its entire left subtree are counted
+			count += avlSubtreeSize(node.Left) + 1
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+
+	return count
+}
+
+// rangeNodes performs an iterative in-order traversal of the subtree, yielding the nodes whose
+// elements lie between the optional lo and hi bounds until yield asks to stop.
+func (t AVLTree[T]) rangeNodes(
+	root *AVLTreeNode[T],
+	lo, hi *T,
+	yield func(*AVLTreeNode[T]) bool,
+) {
+	var stack []*AVLTreeNode[T]
+	node := root
+
+	for node != nil || len(stack) > 0 {
+		// descend left, skipping left subtrees that are entirely below the lower bound
+		for node != nil {
+			if lo != nil && node.Elem < *lo {
+				node = node.Right
+				continue
+			}
+			stack = append(stack, node)
+			node = node.Left
+		}
+		if len(stack) == 0 {
+			return
+		}
+
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if hi != nil && node.Elem > *hi {
+			// everything left to visit is larger still
+			return
+		}
+		if !yield(node) {
+			return
+		}
+		node = node.Right
+	}
+}
+
 func (t AVLTree[T]) contains(root *AVLTreeNode[T], elem T) *AVLTreeNode[T] {
 	if root == nil {
 		return root
@@ -117,19 +318,23 @@ func (t AVLTree[T]) contains(root *AVLTreeNode[T], elem T) *AVLTreeNode[T] {
 	}
 }
 
-func (t *AVLTree[T]) insert(root *AVLTreeNode[T], elem T) (*AVLTreeNode[T], error) {
+// insert adds a node holding the element and the given payload to the subtree and returns the
+// subtree's new root.
+func (t *AVLTree[T]) insert(root *AVLTreeNode[T], elem T, value any) (*AVLTreeNode[T], error) {
 	if root == nil {
-		return NewAVLTreeNode(elem), nil
+		node := NewAVLTreeNode(elem)
+		node.value = value
+		return node, nil
 	}
 
 	if elem < root.Elem {
-		if node, err := t.insert(root.Left, elem); err != nil {
+		if node, err := t.insert(root.Left, elem, value); err != nil {
 			return nil, err
 		} else {
 			root.Left = node
 		}
 	} else if elem > root.Elem {
-		if node, err := t.insert(root.Right, elem); err != nil {
+		if node, err := t.insert(root.Right, elem, value); err != nil {
 			return nil, err
 		} else {
 			root.Right = node
@@ -137,12 +342,12 @@ func (t *AVLTree[T]) insert(root *AVLTreeNode[T], elem T) (*AVLTreeNode[T], erro
 	} else {
 		return nil, errors.New("cannot insert a duplicate element")
 	}
-	// Calculate current height of subtree before balancing
-	root.height = root.GetHeight()
+	// Calculate current height and size of subtree before balancing
+	root.recalculate()
 	// Ensure tree is still balanced
 	root = t.balance(root)
-	// Set the height after balance
-	root.height = root.GetHeight()
+	// Set the height and size after balance
+	root.recalculate()
 
 	return root, nil
 }
@@ -157,14 +362,14 @@ func (t *AVLTree[T]) remove(root *AVLTreeNode[T], elem T) (*AVLTreeNode[T], erro
 			return root, err
 		} else {
 			root.Left = node
-			root.height = root.GetHeight()
+			root.recalculate()
 		}
 	} else if elem > root.Elem {
 		if node, err := t.remove(root.Right, elem); err != nil {
 			return root, err
 		} else {
 			root.Right = node
-			root.height = root.GetHeight()
+			root.recalculate()
 		}
 	} else {
 		// we've found the node to be removed
@@ -190,10 +395,10 @@ func (t *AVLTree[T]) remove(root *AVLTreeNode[T], elem T) (*AVLTreeNode[T], erro
 	}
 
 	if root != nil {
-		root.height = root.GetHeight()
+		root.recalculate()
 		// Ensure tree is still balanced
 		root = t.balance(root)
-		root.height = root.GetHeight()
+		root.recalculate()
 	}
 
 	return root, nil
@@ -238,14 +443,27 @@ func (t *AVLTree[T]) findMin(root *AVLTreeNode[T]) *AVLTreeNode[T] {
 	}
 }
 
+func (t *AVLTree[T]) findMax(root *AVLTreeNode[T]) *AVLTreeNode[T] {
+	if root == nil {
+		return root
+	}
+
+	for root.Right != nil {
+		root = root.Right
+	}
+
+	return root
+}
+
 // leftRotation will perform an AVL Left Rotation and return the new post-rotation root
 func (t *AVLTree[T]) leftRotation(root *AVLTreeNode[T]) *AVLTreeNode[T] {
 	node := root.Right
 	root.Right = node.Left
 	node.Left = root
-	// Recalculate heights for the affected nodes
-	root.height = root.GetHeight()
-	node.height = node.GetHeight()
+	// Recalculate heights and sizes for the affected nodes (children before parents)
+	root.recalculate()
+	node.recalculate()
+	t.rotations++
 	// return the new root
 	return node
 }
@@ -255,9 +473,10 @@ func (t *AVLTree[T]) rightRotation(root *AVLTreeNode[T]) *AVLTreeNode[T] {
 	node := root.Left
 	root.Left = node.Right
 	node.Right = root
-	// Recalculate heights for the affected nodes
-	root.height = root.GetHeight()
-	node.height = node.GetHeight()
+	// Recalculate heights and sizes for the affected nodes (children before parents)
+	root.recalculate()
+	node.recalculate()
+	t.rotations++
 	// return the new root
 	return node
 }
@@ -0,0 +1,342 @@
+package ds
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+// NewAVLTreeFromSorted returns a pointer to a new, perfectly balanced AVL Tree holding the given
+// elements. The elements must be sorted in strictly increasing order; if they are not an error is
+// returned. Building the tree takes O(n) time, compared to O(n log n) for n calls to Insert.
+func NewAVLTreeFromSorted[T constraints.Ordered](elems []T) (*AVLTree[T], error) {
+	for i := 1; i < len(elems); i++ {
+		if elems[i-1] >= elems[i] {
+			return nil, errors.New("elements must be sorted in strictly increasing order")
+		}
+	}
+
+	return &AVLTree[T]{
+		Root: buildAVLTree(elems),
+		size: len(elems),
+	}, nil
+}
+
+// Join returns a new tree holding every element of the tree, the pivot and every element of other.
+// Every element of the tree must be less than the pivot and every element of other must be greater
+// than it; if they are not an error is returned. Both trees are left untouched, so Join runs in
+// O(n + m) time to copy them; see JoinWith to join the trees in O(log n + log m) time instead.
+func (t AVLTree[T]) Join(pivot T, other *AVLTree[T]) (*AVLTree[T], error) {
+	if err := t.checkJoin(pivot, other); err != nil {
+		return nil, err
+	}
+
+	res := t.clone()
+	res.Root = res.join(res.Root, NewAVLTreeNode(pivot), cloneAVLSubtree(other.Root))
+	res.size = avlSubtreeSize(res.Root)
+
+	return res, nil
+}
+
+// JoinWith adds the pivot and every element of other to the tree. Every element of the tree must be
+// less than the pivot and every element of other must be greater than it; if they are not an error
+// is returned and neither tree is modified. Checking the order takes O(log n + log m) time, after
+// which joining the trees takes O(|h(t) - h(other)| + 1) time. JoinWith takes ownership of the
+// nodes of other, leaving it empty.
+func (t *AVLTree[T]) JoinWith(pivot T, other *AVLTree[T]) error {
+	if err := t.checkJoin(pivot, other); err != nil {
+		return err
+	}
+
+	// other may be the tree itself (if it is empty), so empty it before the tree is filled in
+	root := t.join(t.Root, NewAVLTreeNode(pivot), other.Root)
+	other.clear()
+	t.Root = root
+	t.size = avlSubtreeSize(root)
+
+	return nil
+}
+
+// Split divides the tree into a tree holding every element less than the key and a tree holding
+// every element greater than the key; the key itself is not included in either tree. found reports
+// whether the key was in the tree. Split runs in O(log n) time and leaves the original tree empty.
+func (t *AVLTree[T]) Split(key T) (lo *AVLTree[T], hi *AVLTree[T], found bool) {
+	lo = NewAVLTree[T]()
+	hi = NewAVLTree[T]()
+
+	var l, r *AVLTreeNode[T]
+	l, r, found = t.split(t.Root, key)
+	lo.Root, lo.size = l, avlSubtreeSize(l)
+	hi.Root, hi.size = r, avlSubtreeSize(r)
+	t.clear()
+
+	return lo, hi, found
+}
+
+// Union returns a new tree holding every element that is in the tree, other or both. Both trees
+// are left untouched, so Union runs in O(n + m) time to copy them; see UnionWith to merge the trees
+// in O(m log(n/m + 1)) time instead.
+func (t AVLTree[T]) Union(other *AVLTree[T]) *AVLTree[T] {
+	res := t.clone()
+	res.UnionWith(other.clone())
+	return res
+}
+
+// Intersection returns a new tree holding every element that is in both the tree and other. Both
+// trees are left untouched, so Intersection runs in O(n + m) time to copy them; see IntersectWith
+// to intersect the trees in O(m log(n/m + 1)) time instead.
+func (t AVLTree[T]) Intersection(other *AVLTree[T]) *AVLTree[T] {
+	res := t.clone()
+	res.IntersectWith(other.clone())
+	return res
+}
+
+// Difference returns a new tree holding every element of the tree that is not in other. Both trees
+// are left untouched, so Difference runs in O(n + m) time to copy them; see ExceptWith to subtract
+// the trees in O(m log(n/m + 1)) time instead.
+func (t AVLTree[T]) Difference(other *AVLTree[T]) *AVLTree[T] {
+	res := t.clone()
+	res.ExceptWith(other.clone())
+	return res
+}
+
+// UnionWith adds every element of other to the tree, so that the tree holds every element that was
+// in either tree. It runs in O(m log(n/m + 1)) time, where m is the size of the smaller tree, and
+// takes ownership of the nodes of other, leaving it empty (unless other is the tree itself, which
+// is left unchanged).
+func (t *AVLTree[T]) UnionWith(other *AVLTree[T]) {
+	if other == t {
+		return
+	}
+
+	t.Root = t.union(t.Root, other.Root)
+	t.size = avlSubtreeSize(t.Root)
+	other.clear()
+}
+
+// IntersectWith removes every element from the tree that is not also in other. It runs in
+// O(m log(n/m + 1)) time, where m is the size of the smaller tree, and takes ownership of the nodes
+// of other, leaving it empty (unless other is the tree itself, which is left unchanged).
+func (t *AVLTree[T]) IntersectWith(other *AVLTree[T]) {
+	if other == t {
+		return
+	}
+
+	t.Root = t.intersection(t.Root, other.Root)
+	t.size = avlSubtreeSize(t.Root)
+	other.clear()
+}
+
+// ExceptWith removes every element from the tree that is also in other. It runs in
+// O(m log(n/m + 1)) time, where m is the size of the smaller tree, and takes ownership of the nodes
+// of other, leaving it empty. If other is the tree itself, the tree is emptied.
+func (t *AVLTree[T]) ExceptWith(other *AVLTree[T]) {
+	if other == t {
+		t.clear()
+		return
+	}
+
+	t.Root = t.difference(t.Root, other.Root)
+	t.size = avlSubtreeSize(t.Root)
+	other.clear()
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// buildAVLTree builds a perfectly balanced subtree from sorted elements by making the middle
+// element the root and building each half recursively.
+func buildAVLTree[T constraints.Ordered](elems []T) *AVLTreeNode[T] {
+	if len(elems) == 0 {
+		return nil
+	}
+
+	mid := len(elems) / 2
+	node := NewAVLTreeNode(elems[mid])
+	node.Left = buildAVLTree(elems[:mid])
+	node.Right = buildAVLTree(elems[mid+1:])
+	node.recalculate()
+
+	return node
+}
+
+// avlSubtreeHeight returns the height of the subtree rooted at the given node, which may be nil;
+// the height of an empty subtree is -1.
+func avlSubtreeHeight[T constraints.Ordered](n *AVLTreeNode[T]) int {
+	if n == nil {
+		return -1
+	}
+	return n.height
+}
+
+// clone returns a copy of the tree that shares no nodes with it.
+func (t AVLTree[T]) clone() *AVLTree[T] {
+	return &AVLTree[T]{
+		Root: cloneAVLSubtree(t.Root),
+		size: t.size,
+	}
+}
+
+// cloneAVLSubtree returns a copy of the subtree rooted at the given node, which may be nil.
+func cloneAVLSubtree[T constraints.Ordered](n *AVLTreeNode[T]) *AVLTreeNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	cpy := *n
+	cpy.Left = cloneAVLSubtree(n.Left)
+	cpy.Right = cloneAVLSubtree(n.Right)
+
+	return &cpy
+}
+
+// checkJoin returns an error unless every element of the tree is less than the pivot and every
+// element of other is greater than it.
+func (t AVLTree[T]) checkJoin(pivot T, other *AVLTree[T]) error {
+	if max, err := t.Max(); err == nil && max >= pivot {
+		return errors.New("every element of the tree must be less than the pivot")
+	}
+	if min, err := other.Min(); err == nil && min <= pivot {
+		return errors.New("every element of the other tree must be greater than the pivot")
+	}
+
+	return nil
+}
+
+// clear empties the tree after its nodes have been handed over to another tree.
+func (t *AVLTree[T]) clear() {
+	t.Root = nil
+	t.size = 0
+}
+
+// join returns the root of a balanced subtree holding the left subtree, the pivot node and the
+// right subtree. It descends the spine of the taller subtree until it finds a subtree whose height
+// is close to the shorter one, attaches the pivot there and rebalances on the way back up.
+func (t *AVLTree[T]) join(left, pivot, right *AVLTreeNode[T]) *AVLTreeNode[T] {
+	lh, rh := avlSubtreeHeight(left), avlSubtreeHeight(right)
+
+	var root *AVLTreeNode[T]
+	if lh > rh+1 {
+		left.Right = t.join(left.Right, pivot, right)
+		root = left
+	} else if rh > lh+1 {
+		right.Left = t.join(left, pivot, right.Left)
+		root = right
+	} else {
+		pivot.Left = left
+		pivot.Right = right
+		pivot.recalculate()
+		return pivot
+	}
+
+	root.recalculate()
+	root = t.balance(root)
+	root.recalculate()
+
+	return root
+}
+
+// join2 returns the root of a balanced subtree holding every element of both subtrees, where every
+// element of the left subtree is less than every element of the right subtree.
+func (t *AVLTree[T]) join2(left, right *AVLTreeNode[T]) *AVLTreeNode[T] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+
+	rest, min := t.detachMin(right)
+	return t.join(left, min, rest)
+}
+
+// detachMin unlinks the minimum node of the subtree and returns the rebalanced remainder of the
+// subtree along with the detached node.
+func (t *AVLTree[T]) detachMin(root *AVLTreeNode[T]) (*AVLTreeNode[T], *AVLTreeNode[T]) {
+	if root.Left == nil {
+		rest := root.Right
+		root.Right = nil
+		root.recalculate()
+		return rest, root
+	}
+
+	rest, min := t.detachMin(root.Left)
+	root.Left = rest
+	root.recalculate()
+	root = t.balance(root)
+	root.recalculate()
+
+	return root, min
+}
+
+// split divides the subtree into the elements less than and greater than the key, re-joining the
+// pieces cut off along the search path. The node holding the key, if any, is discarded.
+func (t *AVLTree[T]) split(root *AVLTreeNode[T], key T) (*AVLTreeNode[T], *AVLTreeNode[T], bool) {
+	if root == nil {
+		return nil, nil, false
+	}
+
+	left, right := root.Left, root.Right
+	root.Left, root.Right = nil, nil
+
+	if key < root.Elem {
+		l, r, found := t.split(left, key)
+		return l, t.join(r, root, right), found
+	} else if key > root.Elem {
+		l, r, found := t.split(right, key)
+		return t.join(left, root, l), r, found
+	}
+
+	return left, right, true
+}
+
+// union splits b around the root of a, recursively merges the matching halves and joins the results
+// back together with the root of a as the pivot.
+func (t *AVLTree[T]) union(a, b *AVLTreeNode[T]) *AVLTreeNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	l, r, _ := t.split(b, a.Elem)
+	left, right := a.Left, a.Right
+	a.Left, a.Right = nil, nil
+
+	return t.join(t.union(left, l), a, t.union(right, r))
+}
+
+// intersection splits b around the root of a, recursively intersects the matching halves and joins
+// the results back together, keeping the root of a only if it was also found in b.
+func (t *AVLTree[T]) intersection(a, b *AVLTreeNode[T]) *AVLTreeNode[T] {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	l, r, found := t.split(b, a.Elem)
+	left, right := a.Left, a.Right
+	a.Left, a.Right = nil, nil
+
+	il := t.intersection(left, l)
+	ir := t.intersection(right, r)
+	if found {
+		return t.join(il, a, ir)
+	}
+	return t.join2(il, ir)
+}
+
+// difference splits a around the root of b, recursively removes the matching halves of b and joins
+// the results back together without the root of b.
+func (t *AVLTree[T]) difference(a, b *AVLTreeNode[T]) *AVLTreeNode[T] {
+	if a == nil {
+		return nil
+	}
+	if b == nil {
+		return a
+	}
+
+	l, r, _ := t.split(a, b.Elem)
+
+	return t.join2(t.difference(l, b.Left), t.difference(r, b.Right))
+}
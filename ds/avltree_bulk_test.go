@@ -0,0 +1,310 @@
+package ds_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSortedAVLTree builds a tree from the elements, which must already be strictly increasing.
+func newSortedAVLTree(t *testing.T, elems []int) *ds.AVLTree[int] {
+	tree, err := ds.NewAVLTreeFromSorted(elems)
+	assert.Nil(t, err)
+	return tree
+}
+
+// randomSet returns the sorted, de-duplicated values of n random ints in [0, limit).
+func randomSet(r *rand.Rand, n, limit int) []int {
+	elems := make([]int, n)
+	for i := range elems {
+		elems[i] = r.Intn(limit)
+	}
+	slices.Sort(elems)
+	return slices.Compact(elems)
+}
+
+// collectAVLTree returns the elements of the tree in order, as a non-nil slice.
+func collectAVLTree(tree *ds.AVLTree[int]) []int {
+	return append([]int{}, slices.Collect(tree.All())...)
+}
+
+func TestNewAVLTreeFromSorted(t *testing.T) {
+	t.Run("Should build an empty tree from an empty slice", func(t *testing.T) {
+		tree := newSortedAVLTree(t, nil)
+		assert.Equal(t, 0, tree.Size())
+		assert.Nil(t, tree.Root)
+	})
+
+	t.Run("Should build a balanced tree holding every element", func(t *testing.T) {
+		for _, n := range []int{1, 2, 3, 7, 8, 100, 1023, 1024} {
+			elems := make([]int, n)
+			for i := range elems {
+				elems[i] = i * 3
+			}
+			tree := newSortedAVLTree(t, elems)
+			assert.Equal(t, n, tree.Size())
+			assert.Equal(t, elems, collectAVLTree(tree))
+			checkAVLNodes(t, tree.Root)
+			// a perfectly balanced tree has the minimum possible height
+			assert.LessOrEqual(t, 1<<tree.Root.GetHeight(), n)
+		}
+	})
+
+	t.Run("Should support inserts and removes after building", func(t *testing.T) {
+		tree := newSortedAVLTree(t, []int{10, 20, 30})
+		assert.Nil(t, tree.Insert(25))
+		assert.Nil(t, tree.Remove(10))
+		assert.Equal(t, []int{20, 25, 30}, collectAVLTree(tree))
+		assert.Equal(t, 3, tree.Size())
+	})
+
+	t.Run("Should return an error if the elements are not strictly increasing", func(t *testing.T) {
+		_, err := ds.NewAVLTreeFromSorted([]int{1, 3, 2})
+		assert.NotNil(t, err)
+		_, err = ds.NewAVLTreeFromSorted([]int{1, 2, 2, 3})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAVLTreeJoin(t *testing.T) {
+	t.Run("Should join two empty trees around a pivot", func(t *testing.T) {
+		tree, err := ds.NewAVLTree[int]().Join(5, ds.NewAVLTree[int]())
+		assert.Nil(t, err)
+		assert.Equal(t, []int{5}, collectAVLTree(tree))
+		assert.Equal(t, 1, tree.Size())
+	})
+
+	t.Run("Should join trees of very different heights and leave them untouched", func(t *testing.T) {
+		large := make([]int, 1000)
+		for i := range large {
+			large[i] = i + 10
+		}
+
+		t1 := newSortedAVLTree(t, []int{1, 2})
+		t2 := newSortedAVLTree(t, large)
+		tree, err := t1.Join(5, t2)
+		assert.Nil(t, err)
+		assert.Equal(t, append([]int{1, 2, 5}, large...), collectAVLTree(tree))
+		assert.Equal(t, 1003, tree.Size())
+		checkAVLNodes(t, tree.Root)
+		assert.Equal(t, []int{1, 2}, collectAVLTree(t1))
+		assert.Equal(t, large, collectAVLTree(t2))
+
+		// the result shares no nodes with the inputs
+		tree.Insert(3)
+		assert.Equal(t, []int{1, 2}, collectAVLTree(t1))
+		assert.Equal(t, 2, t1.Size())
+
+		t1 = newSortedAVLTree(t, large)
+		t2 = newSortedAVLTree(t, []int{2001})
+		tree, err = t1.Join(2000, t2)
+		assert.Nil(t, err)
+		assert.Equal(t, append(slices.Clone(large), 2000, 2001), collectAVLTree(tree))
+		assert.Equal(t, 1002, tree.Size())
+		checkAVLNodes(t, tree.Root)
+	})
+
+	t.Run("Should return an error if the pivot is out of order", func(t *testing.T) {
+		t1 := newSortedAVLTree(t, []int{1, 2, 3})
+		t2 := newSortedAVLTree(t, []int{7, 8, 9})
+
+		_, err := t1.Join(3, t2)
+		assert.NotNil(t, err)
+		_, err = t1.Join(7, t2)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAVLTreeJoinWith(t *testing.T) {
+	t.Run("Should join trees of very different heights in place", func(t *testing.T) {
+		large := make([]int, 1000)
+		for i := range large {
+			large[i] = i + 10
+		}
+
+		tree := newSortedAVLTree(t, []int{1, 2})
+		other := newSortedAVLTree(t, large)
+		err := tree.JoinWith(5, other)
+		assert.Nil(t, err)
+		assert.Equal(t, append([]int{1, 2, 5}, large...), collectAVLTree(tree))
+		assert.Equal(t, 1003, tree.Size())
+		checkAVLNodes(t, tree.Root)
+		assert.Equal(t, 0, other.Size())
+		assert.Nil(t, other.Root)
+	})
+
+	t.Run("Should join an empty tree with itself", func(t *testing.T) {
+		tree := ds.NewAVLTree[int]()
+		err := tree.JoinWith(5, tree)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{5}, collectAVLTree(tree))
+		assert.Equal(t, 1, tree.Size())
+	})
+
+	t.Run("Should return an error and leave the trees unchanged if the pivot is out of order", func(t *testing.T) {
+		t1 := newSortedAVLTree(t, []int{1, 2, 3})
+		t2 := newSortedAVLTree(t, []int{7, 8, 9})
+
+		err := t1.JoinWith(3, t2)
+		assert.NotNil(t, err)
+		err = t1.JoinWith(7, t2)
+		assert.NotNil(t, err)
+		err = t1.JoinWith(5, t1)
+		assert.NotNil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, collectAVLTree(t1))
+		assert.Equal(t, []int{7, 8, 9}, collectAVLTree(t2))
+	})
+}
+
+func TestAVLTreeSplit(t *testing.T) {
+	t.Run("Should split an empty tree into two empty trees", func(t *testing.T) {
+		lo, hi, found := ds.NewAVLTree[int]().Split(1)
+		assert.False(t, found)
+		assert.Equal(t, 0, lo.Size())
+		assert.Equal(t, 0, hi.Size())
+	})
+
+	t.Run("Should split around every key", func(t *testing.T) {
+		elems := make([]int, 200)
+		for i := range elems {
+			elems[i] = i * 2
+		}
+
+		for key := -1; key <= 400; key++ {
+			tree := newSortedAVLTree(t, elems)
+			lo, hi, found := tree.Split(key)
+			assert.Equal(t, key >= 0 && key < 400 && key%2 == 0, found)
+
+			i, _ := slices.BinarySearch(elems, key)
+			j := i
+			if found {
+				j++
+			}
+			assert.Equal(t, elems[:i], collectAVLTree(lo))
+			assert.Equal(t, elems[j:], collectAVLTree(hi))
+			assert.Equal(t, i, lo.Size())
+			assert.Equal(t, len(elems)-j, hi.Size())
+			checkAVLNodes(t, lo.Root)
+			checkAVLNodes(t, hi.Root)
+			assert.Equal(t, 0, tree.Size())
+			assert.Nil(t, tree.Root)
+		}
+	})
+}
+
+func TestAVLTreeSetOperations(t *testing.T) {
+	t.Run("Should handle empty trees", func(t *testing.T) {
+		elems := []int{1, 2, 3}
+		for _, tc := range []struct {
+			tree, other *ds.AVLTree[int]
+			op          func(t, other *ds.AVLTree[int])
+			want        []int
+		}{
+			{newSortedAVLTree(t, elems), ds.NewAVLTree[int](), (*ds.AVLTree[int]).UnionWith, elems},
+			{ds.NewAVLTree[int](), newSortedAVLTree(t, elems), (*ds.AVLTree[int]).UnionWith, elems},
+			{newSortedAVLTree(t, elems), ds.NewAVLTree[int](), (*ds.AVLTree[int]).IntersectWith, []int{}},
+			{newSortedAVLTree(t, elems), ds.NewAVLTree[int](), (*ds.AVLTree[int]).ExceptWith, elems},
+			{ds.NewAVLTree[int](), newSortedAVLTree(t, elems), (*ds.AVLTree[int]).ExceptWith, []int{}},
+		} {
+			tc.op(tc.tree, tc.other)
+			assert.Equal(t, tc.want, collectAVLTree(tc.tree))
+			assert.Equal(t, 0, tc.other.Size())
+		}
+	})
+
+	t.Run("Should match a map-based oracle on random sets", func(t *testing.T) {
+		r := rand.New(rand.NewSource(15))
+		for range 200 {
+			a := randomSet(r, r.Intn(300), 500)
+			b := randomSet(r, r.Intn(300), 500)
+
+			inB := map[int]bool{}
+			for _, e := range b {
+				inB[e] = true
+			}
+			union := slices.Compact(slices.Sorted(slices.Values(append(slices.Clone(a), b...))))
+			intersection := []int{}
+			difference := []int{}
+			for _, e := range a {
+				if inB[e] {
+					intersection = append(intersection, e)
+				} else {
+					difference = append(difference, e)
+				}
+			}
+
+			for _, tc := range []struct {
+				op      func(t ds.AVLTree[int], other *ds.AVLTree[int]) *ds.AVLTree[int]
+				inPlace func(t, other *ds.AVLTree[int])
+				want    []int
+			}{
+				{ds.AVLTree[int].Union, (*ds.AVLTree[int]).UnionWith, union},
+				{ds.AVLTree[int].Intersection, (*ds.AVLTree[int]).IntersectWith, intersection},
+				{ds.AVLTree[int].Difference, (*ds.AVLTree[int]).ExceptWith, difference},
+			} {
+				t1 := newSortedAVLTree(t, a)
+				t2 := newSortedAVLTree(t, b)
+				tree := tc.op(*t1, t2)
+				assert.Equal(t, append([]int{}, tc.want...), collectAVLTree(tree))
+				assert.Equal(t, len(tc.want), tree.Size())
+				checkAVLNodes(t, tree.Root)
+				assert.Equal(t, append([]int{}, a...), collectAVLTree(t1), "Should not have side effects")
+				assert.Equal(t, append([]int{}, b...), collectAVLTree(t2), "Should not have side effects")
+
+				tc.inPlace(t1, t2)
+				assert.Equal(t, append([]int{}, tc.want...), collectAVLTree(t1))
+				assert.Equal(t, len(tc.want), t1.Size())
+				checkAVLNodes(t, t1.Root)
+				assert.Equal(t, 0, t2.Size())
+			}
+		}
+	})
+
+	t.Run("Should handle a tree combined with itself", func(t *testing.T) {
+		elems := []int{1, 2, 3, 4, 5, 6, 7}
+
+		tree := newSortedAVLTree(t, elems)
+		tree.UnionWith(tree)
+		assert.Equal(t, elems, collectAVLTree(tree))
+		assert.Equal(t, len(elems), tree.Size())
+
+		tree.IntersectWith(tree)
+		assert.Equal(t, elems, collectAVLTree(tree))
+		assert.Equal(t, len(elems), tree.Size())
+
+		assert.Equal(t, elems, collectAVLTree(tree.Union(tree)))
+		assert.Equal(t, elems, collectAVLTree(tree.Intersection(tree)))
+		assert.Equal(t, []int{}, collectAVLTree(tree.Difference(tree)))
+		assert.Equal(t, elems, collectAVLTree(tree))
+
+		tree.ExceptWith(tree)
+		assert.Equal(t, []int{}, collectAVLTree(tree))
+		assert.Equal(t, 0, tree.Size())
+		assert.Nil(t, tree.Root)
+	})
+}
+
+func BenchmarkAVLTreeBuild(b *testing.B) {
+	elems := make([]int, 1_000_000)
+	for i := range elems {
+		elems[i] = i
+	}
+
+	b.Run("FromSorted", func(b *testing.B) {
+		for range b.N {
+			ds.NewAVLTreeFromSorted(elems)
+		}
+	})
+
+	b.Run("Insert", func(b *testing.B) {
+		for range b.N {
+			tree := ds.NewAVLTree[int]()
+			for _, e := range elems {
+				tree.Insert(e)
+			}
+		}
+	})
+}
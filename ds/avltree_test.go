@@ -1,6 +1,8 @@
 package ds_test
 
 import (
+	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/bcdxn/dsa-go/ds"
@@ -284,3 +286,171 @@ func TestAvlTreeRemove(t *testing.T) {
 		assert.Equal(t, 30, tree.Root.Right.Right.Elem)
 	})
 }
+
+// newQueryTree returns an AVL tree holding the even numbers from 0 to 20.
+func newQueryTree() *ds.AVLTree[int] {
+	tree := ds.NewAVLTree[int]()
+	for i := 0; i <= 20; i += 2 {
+		tree.Insert(i)
+	}
+	return tree
+}
+
+func TestAVLTreeMinMax(t *testing.T) {
+	t.Run("Min and Max on an empty tree", func(t *testing.T) {
+		tree := ds.NewAVLTree[int]()
+		_, err := tree.Min()
+		assert.NotNil(t, err)
+		_, err = tree.Max()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Min and Max on a populated tree", func(t *testing.T) {
+		tree := newQueryTree()
+		min, err := tree.Min()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, min)
+		max, err := tree.Max()
+		assert.Nil(t, err)
+		assert.Equal(t, 20, max)
+	})
+}
+
+func TestAVLTreeNeighbors(t *testing.T) {
+	tree := newQueryTree()
+
+	tests := []struct {
+		description string
+		query       func(int) (int, error)
+		elem        int
+		expected    int
+		found       bool
+	}{
+		{"Floor of a present element", tree.Floor, 10, 10, true},
+		{"Floor between elements", tree.Floor, 11, 10, true},
+		{"Floor below the minimum", tree.Floor, -1, 0, false},
+		{"Ceiling of a present element", tree.Ceiling, 10, 10, true},
+		{"Ceiling between elements", tree.Ceiling, 11, 12, true},
+		{"Ceiling above the maximum", tree.Ceiling, 21, 0, false},
+		{"Lower of a present element", tree.Lower, 10, 8, true},
+		{"Lower between elements", tree.Lower, 11, 10, true},
+		{"Lower of the minimum", tree.Lower, 0, 0, false},
+		{"Higher of a present element", tree.Higher, 10, 12, true},
+		{"Higher between elements", tree.Higher, 11, 12, true},
+		{"Higher of the maximum", tree.Higher, 20, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			elem, err := test.query(test.elem)
+			if test.found {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, elem)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestAVLTreeRange(t *testing.T) {
+	t.Run("All should yield every element in order", func(t *testing.T) {
+		tree := ds.NewAVLTree[int]()
+		for _, e := range []int{10, 5, 15, 7, 3, 24, 13, 30} {
+			tree.Insert(e)
+		}
+		assert.Equal(t, []int{3, 5, 7, 10, 13, 15, 24, 30}, slices.Collect(tree.All()))
+	})
+
+	t.Run("Range should be inclusive of both bounds", func(t *testing.T) {
+		tree := newQueryTree()
+		assert.Equal(t, []int{4, 6, 8, 10}, slices.Collect(tree.Range(4, 10)))
+		assert.Equal(t, []int{4, 6, 8, 10}, slices.Collect(tree.Range(3, 11)))
+	})
+
+	t.Run("Range outside of the tree should be empty", func(t *testing.T) {
+		tree := newQueryTree()
+		assert.Empty(t, slices.Collect(tree.Range(21, 30)))
+		assert.Empty(t, slices.Collect(tree.Range(11, 11)))
+		assert.Empty(t, slices.Collect(tree.Range(10, 4)))
+	})
+
+	t.Run("Range should stop when the consumer stops", func(t *testing.T) {
+		tree := newQueryTree()
+		var seen []int
+		for e := range tree.Range(0, 20) {
+			seen = append(seen, e)
+			if len(seen) == 3 {
+				break
+			}
+		}
+		assert.Equal(t, []int{0, 2, 4}, seen)
+	})
+}
+
+func TestAVLTreeOrderStatistics(t *testing.T) {
+	t.Run("Select on an empty tree", func(t *testing.T) {
+		tree := ds.NewAVLTree[int]()
+		_, err := tree.Select(0)
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, tree.Rank(10))
+		assert.Equal(t, 0, tree.CountRange(0, 10))
+	})
+
+	t.Run("Select, Rank and CountRange on a populated tree", func(t *testing.T) {
+		tree := newQueryTree()
+
+		for k := range 11 {
+			elem, err := tree.Select(k)
+			assert.Nil(t, err)
+			assert.Equal(t, k*2, elem)
+			assert.Equal(t, k, tree.Rank(k*2))
+		}
+		_, err := tree.Select(11)
+		assert.NotNil(t, err)
+		_, err = tree.Select(-1)
+		assert.NotNil(t, err)
+
+		assert.Equal(t, 3, tree.Rank(5))
+		assert.Equal(t, 0, tree.Rank(-5))
+		assert.Equal(t, 11, tree.Rank(50))
+
+		assert.Equal(t, 4, tree.CountRange(4, 10))
+		assert.Equal(t, 4, tree.CountRange(3, 11))
+		assert.Equal(t, 11, tree.CountRange(-100, 100))
+		assert.Equal(t, 0, tree.CountRange(11, 11))
+		assert.Equal(t, 1, tree.CountRange(10, 10))
+		assert.Equal(t, 0, tree.CountRange(10, 4))
+	})
+
+	t.Run("Subtree sizes should survive random inserts and removes", func(t *testing.T) {
+		r := rand.New(rand.NewSource(9))
+		tree := ds.NewAVLTree[int]()
+		var oracle []int
+
+		for range 2000 {
+			elem := r.Intn(500)
+			i, found := slices.BinarySearch(oracle, elem)
+			if r.Intn(3) == 0 {
+				if found {
+					assert.Nil(t, tree.Remove(elem))
+					oracle = slices.Delete(oracle, i, i+1)
+				} else {
+					assert.NotNil(t, tree.Remove(elem))
+				}
+			} else if !found {
+				assert.Nil(t, tree.Insert(elem))
+				oracle = slices.Insert(oracle, i, elem)
+			}
+		}
+
+		assert.Equal(t, len(oracle), tree.Size())
+		for k, expected := range oracle {
+			elem, err := tree.Select(k)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, elem)
+			assert.Equal(t, k, tree.Rank(expected))
+		}
+		assert.Equal(t, len(oracle), tree.CountRange(0, 500))
+	})
+}
@@ -0,0 +1,232 @@
+package ds
+
+import "errors"
+
+// BinomialHeapNode is an element of a BinomialHeap. The node returned when an element is inserted
+// is used to decrease the element's key later on.
+type BinomialHeapNode[T any] struct {
+	elem T
+	// tree is the position of the element in the heap; elements move between tree positions as
+	// their keys decrease
+	tree *binomialTree[T]
+	// removed is set once the node has been popped from the heap
+	removed bool
+}
+
+// Value returns the element stored in the node.
+func (n BinomialHeapNode[T]) Value() T {
+	return n.elem
+}
+
+// binomialTree is a position in a binomial tree. A tree of degree k has 2^k positions; its root has
+// k children, which are themselves the roots of trees of degree k-1 down to 0.
+type binomialTree[T any] struct {
+	node    *BinomialHeapNode[T]
+	parent  *binomialTree[T]
+	child   *binomialTree[T]
+	sibling *binomialTree[T]
+	degree  int
+}
+
+// BinomialHeap is a collection of heap-ordered binomial trees, at most one of each degree, ordered
+// by a less function. Like the binary representation of its size, the heap holds O(log n) trees,
+// which lets two heaps be melded in O(log n) time by adding their trees together with carries.
+type BinomialHeap[T any] struct {
+	// head is the first of the root trees, which are linked through their siblings in increasing
+	// order of degree
+	head *binomialTree[T]
+	size int
+	less func(a, b T) bool
+}
+
+// NewBinomialHeap returns a new empty heap ordered by the given less function, which reports
+// whether a belongs closer to the top of the heap than b.
+func NewBinomialHeap[T any](less func(a, b T) bool) *BinomialHeap[T] {
+	return &BinomialHeap[T]{
+		head: nil,
+		size: 0,
+		less: less,
+	}
+}
+
+// Size returns the number of elements currently in the heap
+func (h BinomialHeap[T]) Size() int {
+	return h.size
+}
+
+// Peek returns the element at the top of the heap without removing it, in O(log n) time
+func (h BinomialHeap[T]) Peek() (T, error) {
+	if h.head == nil {
+		var empty T
+		return empty, errors.New("cannot peek an empty heap")
+	}
+
+	top, _ := h.top()
+	return top.node.elem, nil
+}
+
+// Push adds an element to the heap in O(log n) time
+func (h *BinomialHeap[T]) Push(elem T) {
+	h.Insert(elem)
+}
+
+// Insert adds an element to the heap in O(log n) time and returns the node holding it, which can be
+// passed to DecreaseKey.
+func (h *BinomialHeap[T]) Insert(elem T) *BinomialHeapNode[T] {
+	node := &BinomialHeapNode[T]{elem: elem}
+	node.tree = &binomialTree[T]{node: node}
+	h.head = h.union(h.head, node.tree)
+	h.size++
+
+	return node
+}
+
+// Pop removes the element at the top of the heap in O(log n) time. The children of the removed
+// root form a binomial heap of their own, which is melded back into the heap.
+func (h *BinomialHeap[T]) Pop() (T, error) {
+	if h.head == nil {
+		var empty T
+		return empty, errors.New("cannot pop an empty heap")
+	}
+
+	top, prev := h.top()
+	if prev == nil {
+		h.head = top.sibling
+	} else {
+		prev.sibling = top.sibling
+	}
+
+	// the children are linked in decreasing order of degree; reverse them to form a root list
+	var children *binomialTree[T]
+	for child := top.child; child != nil; {
+		next := child.sibling
+		child.parent = nil
+		child.sibling = children
+		children = child
+		child = next
+	}
+	h.head = h.union(h.head, children)
+
+	h.size--
+	top.node.removed = true
+
+	return top.node.elem, nil
+}
+
+// DecreaseKey replaces the node's element with one that is no further from the top of the heap and
+// moves the element up its tree, in O(log n) time. If the new element belongs further from the top
+// of the heap than the current one, or the node is no longer in the heap, an error is returned. The
+// node must have been inserted into this heap or into a heap that has since been melded into it.
+func (h *BinomialHeap[T]) DecreaseKey(node *BinomialHeapNode[T], elem T) error {
+	if node.removed {
+		return errors.New("node is not in the heap")
+	}
+	if h.less(node.elem, elem) {
+		return errors.New("new element belongs further from the top of the heap")
+	}
+
+	node.elem = elem
+	// swap the element with its parent's until the tree is heap-ordered again
+	tree := node.tree
+	for tree.parent != nil && h.less(tree.node.elem, tree.parent.node.elem) {
+		parent := tree.parent
+		tree.node, parent.node = parent.node, tree.node
+		tree.node.tree = tree
+		parent.node.tree = parent
+		tree = parent
+	}
+
+	return nil
+}
+
+// Meld moves every element of the other heap into this heap in O(log n) time, leaving the other
+// heap empty. Both heaps must be ordered by the same less function.
+func (h *BinomialHeap[T]) Meld(other *BinomialHeap[T]) {
+	if other == h {
+		return
+	}
+
+	h.head = h.union(h.head, other.head)
+	h.size += other.size
+	other.head = nil
+	other.size = 0
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// top returns the root tree holding the element at the top of the heap, along with the root before
+// it (nil if it is the first root).
+func (h BinomialHeap[T]) top() (*binomialTree[T], *binomialTree[T]) {
+	top, topPrev := h.head, (*binomialTree[T])(nil)
+	for prev, tree := h.head, h.head.sibling; tree != nil; prev, tree = tree, tree.sibling {
+		if h.less(tree.node.elem, top.node.elem) {
+			top, topPrev = tree, prev
+		}
+	}
+
+	return top, topPrev
+}
+
+// union combines two root lists into one, linking trees of equal degree until at most one tree of
+// each degree remains, and returns the head of the combined list.
+func (h *BinomialHeap[T]) union(a, b *binomialTree[T]) *binomialTree[T] {
+	head := mergeBinomialRoots(a, b)
+	if head == nil {
+		return nil
+	}
+
+	var prev *binomialTree[T]
+	tree, next := head, head.sibling
+	for next != nil {
+		if tree.degree != next.degree || (next.sibling != nil && next.sibling.degree == tree.degree) {
+			// nothing to link here (or the next two trees should be linked first); move along
+			prev = tree
+			tree = next
+		} else if !h.less(next.node.elem, tree.node.elem) {
+			tree.sibling = next.sibling
+			linkBinomialTrees(next, tree)
+		} else {
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			linkBinomialTrees(tree, next)
+			tree = next
+		}
+		next = tree.sibling
+	}
+
+	return head
+}
+
+// mergeBinomialRoots merges two root lists into a single list ordered by degree.
+func mergeBinomialRoots[T any](a, b *binomialTree[T]) *binomialTree[T] {
+	var head binomialTree[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+
+	return head.sibling
+}
+
+// linkBinomialTrees makes the child tree the first child of the parent tree; both trees must have
+// the same degree.
+func linkBinomialTrees[T any](child, parent *binomialTree[T]) {
+	child.parent = parent
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree++
+}
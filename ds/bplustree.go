@@ -0,0 +1,579 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// bPlusTreeNode is a single node in a B+Tree. Leaves store the elements of the tree and are linked
+// together in ascending order. Internal nodes store only separator keys: children[i] holds the
+// elements that are greater than or equal to keys[i-1] and less than keys[i].
+type bPlusTreeNode[T constraints.Ordered] struct {
+	keys []T
+	// children is nil for leaf nodes
+	children []*bPlusTreeNode[T]
+	// next links a leaf to the leaf holding the next largest elements
+	next *bPlusTreeNode[T]
+	// size is the number of elements in the leaves of the subtree rooted at this node
+	size int
+}
+
+// BPlusTree is an implementation of a B+Tree: a B-Tree variant that stores every element in its
+// leaves and links the leaves together, so that range scans walk contiguous slices of elements
+// without returning to the internal nodes.
+type BPlusTree[T constraints.Ordered] struct {
+	root   *bPlusTreeNode[T]
+	degree int
+}
+
+// NewBPlusTree returns a pointer to a new B+Tree with the given minimum degree. Every node other
+// than the root holds between degree-1 and 2*degree-1 keys. Degrees smaller than MinBTreeDegree are
+// raised to MinBTreeDegree.
+func NewBPlusTree[T constraints.Ordered](degree int) *BPlusTree[T] {
+	degree = max(degree, MinBTreeDegree)
+
+	return &BPlusTree[T]{
+		root:   newBPlusTreeNode[T](degree, true),
+		degree: degree,
+	}
+}
+
+// Size returns the number of elements in the tree.
+func (t BPlusTree[T]) Size() int {
+	return t.root.size
+}
+
+// Contains returns true if the specified element is in the tree; else it returns false.
+func (t BPlusTree[T]) Contains(elem T) bool {
+	_, found := slices.BinarySearch(t.findLeaf(elem).keys, elem)
+	return found
+}
+
+// Insert adds an element into the tree; if the element is already in the tree an error is returned.
+func (t *BPlusTree[T]) Insert(elem T) error {
+	separator, sibling, err := t.insert(t.root, elem)
+	if err != nil {
+		return err
+	}
+
+	if sibling != nil {
+		// grow the tree upwards by giving the split root a new parent
+		root := newBPlusTreeNode[T](t.degree, false)
+		root.keys = append(root.keys, separator)
+		root.children = append(root.children, t.root, sibling)
+		root.size = t.root.size + sibling.size
+		t.root = root
+	}
+
+	return nil
+}
+
+// Remove removes an element from the tree; if the element is not in the tree an error is returned.
+func (t *BPlusTree[T]) Remove(elem T) error {
+	if err := t.remove(t.root, elem); err != nil {
+		return err
+	}
+
+	if len(t.root.keys) == 0 && !t.root.isLeaf() {
+		// shrink the tree by dropping the empty root
+		t.root = t.root.children[0]
+	}
+
+	return nil
+}
+
+// Min returns the smallest element in the tree; if the tree is empty an error is returned.
+func (t BPlusTree[T]) Min() (T, error) {
+	if t.Size() == 0 {
+		var empty T
+		return empty, errors.New("tree is empty")
+	}
+
+	return t.firstLeaf().keys[0], nil
+}
+
+// Max returns the largest element in the tree; if the tree is empty an error is returned.
+func (t BPlusTree[T]) Max() (T, error) {
+	if t.Size() == 0 {
+		var empty T
+		return empty, errors.New("tree is empty")
+	}
+
+	return t.root.max(), nil
+}
+
+// Floor returns the largest element in the tree that is less than or equal to the given element; if
+// there is no such element an error is returned.
+func (t BPlusTree[T]) Floor(elem T) (T, error) {
+	return t.below(elem, true)
+}
+
+// Ceiling returns the smallest element in the tree that is greater than or equal to the given
+// element; if there is no such element an error is returned.
+func (t BPlusTree[T]) Ceiling(elem T) (T, error) {
+	return t.above(elem, true)
+}
+
+// Lower returns the largest element in the tree that is strictly less than the given element; if
+// there is no such element an error is returned.
+func (t BPlusTree[T]) Lower(elem T) (T, error) {
+	return t.below(elem, false)
+}
+
+// Higher returns the smallest element in the tree that is strictly greater than the given element;
+// if there is no such element an error is returned.
+func (t BPlusTree[T]) Higher(elem T) (T, error) {
+	return t.above(elem, false)
+}
+
+// Select returns the element with the given 0-based rank, i.e. the k-th smallest element in the
+// tree (Select(0) returns the minimum). If k is out of range an error is returned. Select runs in
+// O(degree * log n) time.
+func (t BPlusTree[T]) Select(k int) (T, error) {
+	if k < 0 || k >= t.Size() {
+		var empty T
+		return empty, errors.New("rank out of range")
+	}
+
+	node := t.root
+	for !node.isLeaf() {
+		i := 0
+		for ; k >= node.children[i].size; i++ {
+			k -= node.children[i].size
+		}
+		node = node.children[i]
+	}
+
+	return node.keys[k], nil
+}
+
+// Rank returns the number of elements in the tree that are strictly less than the given element,
+// which need not be in the tree. When the element is in the tree this is its 0-based position in
+// sorted order.
+func (t BPlusTree[T]) Rank(elem T) int {
+	return t.rank(elem, false)
+}
+
+// CountRange returns the number of elements in the tree between lo and hi (inclusive).
+func (t BPlusTree[T]) CountRange(lo, hi T) int {
+	if hi < lo {
+		return 0
+	}
+
+	return t.rank(hi, true) - t.rank(lo, false)
+}
+
+// All returns an iterator over every element in the tree in ascending order by walking the linked
+// leaves. The tree must not be modified during iteration.
+func (t BPlusTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.scan(t.firstLeaf(), 0, nil, yield)
+	}
+}
+
+// Range returns an iterator over the elements in the tree between lo and hi (inclusive) in
+// ascending order. The internal nodes are only used to find the leaf holding lo; the rest of the
+// range is read by walking the linked leaves. The tree must not be modified during iteration.
+func (t BPlusTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		leaf := t.findLeaf(lo)
+		i, _ := slices.BinarySearch(leaf.keys, lo)
+		t.scan(leaf, i, &hi, yield)
+	}
+}
+
+// Validate checks that the tree satisfies the B+Tree properties: keys are sorted and fall between
+// their separators, every node other than the root holds between degree-1 and 2*degree-1 keys,
+// every leaf is at the same depth, every cached subtree size is correct and the linked leaves hold
+// every element in order. The first violation found is returned as an error.
+func (t BPlusTree[T]) Validate() error {
+	leafDepth := -1
+	var leaves []*bPlusTreeNode[T]
+	if _, err := t.validate(t.root, nil, nil, 0, &leafDepth, &leaves); err != nil {
+		return err
+	}
+
+	for i, leaf := range leaves {
+		var next *bPlusTreeNode[T]
+		if i+1 < len(leaves) {
+			next = leaves[i+1]
+		}
+		if leaf.next != next {
+			return fmt.Errorf("leaf %d is not linked to the leaf that follows it", i)
+		}
+	}
+
+	return nil
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// newBPlusTreeNode returns an empty node with room for one key (and child) more than a full node
+// holds, so that a node can overflow briefly before it is split.
+func newBPlusTreeNode[T constraints.Ordered](degree int, leaf bool) *bPlusTreeNode[T] {
+	node := &bPlusTreeNode[T]{
+		keys: make([]T, 0, 2*degree),
+	}
+	if !leaf {
+		node.children = make([]*bPlusTreeNode[T], 0, 2*degree+1)
+	}
+
+	return node
+}
+
+func (n *bPlusTreeNode[T]) isLeaf() bool {
+	return n.children == nil
+}
+
+// childIndex returns the index of the child whose subtree would hold the element.
+func (n *bPlusTreeNode[T]) childIndex(elem T) int {
+	return bPlusChildIndex(n.keys, elem)
+}
+
+// max returns the largest element in the subtree rooted at the (non-empty) node.
+func (n *bPlusTreeNode[T]) max() T {
+	for !n.isLeaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.keys[len(n.keys)-1]
+}
+
+func (t BPlusTree[T]) maxKeys() int {
+	return 2*t.degree - 1
+}
+
+// rootNode, child, nextLeaf, keysOf and isLeafNode let the search logic shared with DiskBPlusTree
+// walk the tree's nodes in memory; none of them ever returns an error.
+func (t BPlusTree[T]) rootNode() (*bPlusTreeNode[T], error) {
+	return t.root, nil
+}
+
+func (t BPlusTree[T]) child(node *bPlusTreeNode[T], i int) (*bPlusTreeNode[T], error) {
+	return node.children[i], nil
+}
+
+func (t BPlusTree[T]) nextLeaf(leaf *bPlusTreeNode[T]) (*bPlusTreeNode[T], bool, error) {
+	return leaf.next, leaf.next != nil, nil
+}
+
+func (t BPlusTree[T]) keysOf(node *bPlusTreeNode[T]) []T {
+	return node.keys
+}
+
+func (t BPlusTree[T]) isLeafNode(node *bPlusTreeNode[T]) bool {
+	return node.isLeaf()
+}
+
+// findLeaf returns the leaf whose range of elements covers the given element.
+func (t BPlusTree[T]) findLeaf(elem T) *bPlusTreeNode[T] {
+	leaf, _ := bPlusFindLeaf[T, *bPlusTreeNode[T]](t, elem)
+	return leaf
+}
+
+// firstLeaf returns the leaf holding the smallest elements in the tree.
+func (t BPlusTree[T]) firstLeaf() *bPlusTreeNode[T] {
+	node := t.root
+	for !node.isLeaf() {
+		node = node.children[0]
+	}
+	return node
+}
+
+// insert adds the element to the subtree rooted at the node. If the node overflows it is split in
+// two, and the new right-hand sibling is returned along with the separator key that belongs
+// between the two nodes in their parent.
+func (t *BPlusTree[T]) insert(node *bPlusTreeNode[T], elem T) (T, *bPlusTreeNode[T], error) {
+	var separator T
+
+	if node.isLeaf() {
+		i, found := slices.BinarySearch(node.keys, elem)
+		if found {
+			return separator, nil, errors.New("cannot insert a duplicate element")
+		}
+		node.keys = slices.Insert(node.keys, i, elem)
+	} else {
+		i := node.childIndex(elem)
+		childSeparator, sibling, err := t.insert(node.children[i], elem)
+		if err != nil {
+			return separator, nil, err
+		}
+		if sibling != nil {
+			node.keys = slices.Insert(node.keys, i, childSeparator)
+			node.children = slices.Insert(node.children, i+1, sibling)
+		}
+	}
+	node.size++
+
+	if len(node.keys) <= t.maxKeys() {
+		return separator, nil, nil
+	}
+
+	return t.split(node)
+}
+
+// split divides an overflowing node in two and returns the separator key for its parent along with
+// the new right-hand sibling.
+func (t *BPlusTree[T]) split(node *bPlusTreeNode[T]) (T, *bPlusTreeNode[T], error) {
+	mid := len(node.keys) / 2
+	sibling := newBPlusTreeNode[T](t.degree, node.isLeaf())
+
+	var separator T
+	if node.isLeaf() {
+		// the separator is copied up; every element stays in a leaf
+		sibling.keys = append(sibling.keys, node.keys[mid:]...)
+		sibling.size = len(sibling.keys)
+		separator = sibling.keys[0]
+		sibling.next = node.next
+		node.next = sibling
+		clear(node.keys[mid:])
+		node.keys = node.keys[:mid]
+	} else {
+		// the separator moves up; it already appears in a leaf below
+		separator = node.keys[mid]
+		sibling.keys = append(sibling.keys, node.keys[mid+1:]...)
+		sibling.children = append(sibling.children, node.children[mid+1:]...)
+		for _, child := range sibling.children {
+			sibling.size += child.size
+		}
+		clear(node.keys[mid:])
+		node.keys = node.keys[:mid]
+		clear(node.children[mid+1:]) // clear to help GC
+		node.children = node.children[:mid+1]
+	}
+	node.size -= sibling.size
+
+	return separator, sibling, nil
+}
+
+// remove deletes the element from the subtree rooted at the node, rebalancing any child that drops
+// below the minimum number of keys on the way back up.
+func (t *BPlusTree[T]) remove(node *bPlusTreeNode[T], elem T) error {
+	if node.isLeaf() {
+		i, found := slices.BinarySearch(node.keys, elem)
+		if !found {
+			return errors.New("element not found in the tree")
+		}
+		node.keys = slices.Delete(node.keys, i, i+1)
+		node.size--
+		return nil
+	}
+
+	i := node.childIndex(elem)
+	if err := t.remove(node.children[i], elem); err != nil {
+		return err
+	}
+	node.size--
+
+	if len(node.children[i].keys) < t.degree-1 {
+		t.rebalance(node, i)
+	}
+
+	return nil
+}
+
+// rebalance tops up the underfull child at index i of the node by borrowing from a sibling, or by
+// merging it with a sibling when neither can spare a key.
+func (t *BPlusTree[T]) rebalance(node *bPlusTreeNode[T], i int) {
+	child := node.children[i]
+
+	if i > 0 && len(node.children[i-1].keys) > t.degree-1 {
+		left := node.children[i-1]
+		moved := 1
+		if child.isLeaf() {
+			child.keys = slices.Insert(child.keys, 0, left.keys[len(left.keys)-1])
+			node.keys[i-1] = child.keys[0]
+		} else {
+			last := left.children[len(left.children)-1]
+			left.children[len(left.children)-1] = nil // clear to help GC
+			left.children = left.children[:len(left.children)-1]
+			child.children = slices.Insert(child.children, 0, last)
+			child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+			node.keys[i-1] = left.keys[len(left.keys)-1]
+			moved = last.size
+		}
+		left.keys = left.keys[:len(left.keys)-1]
+		child.size += moved
+		left.size -= moved
+		return
+	}
+
+	if i < len(node.keys) && len(node.children[i+1].keys) > t.degree-1 {
+		right := node.children[i+1]
+		moved := 1
+		if child.isLeaf() {
+			child.keys = append(child.keys, right.keys[0])
+			right.keys = slices.Delete(right.keys, 0, 1)
+			node.keys[i] = right.keys[0]
+		} else {
+			first := right.children[0]
+			right.children = slices.Delete(right.children, 0, 1)
+			child.children = append(child.children, first)
+			child.keys = append(child.keys, node.keys[i])
+			node.keys[i] = right.keys[0]
+			right.keys = slices.Delete(right.keys, 0, 1)
+			moved = first.size
+		}
+		child.size += moved
+		right.size -= moved
+		return
+	}
+
+	if i < len(node.keys) {
+		t.merge(node, i)
+	} else {
+		t.merge(node, i-1)
+	}
+}
+
+// merge folds the child to the right of the separator at index i into the child to its left and
+// removes the separator from the node.
+func (t *BPlusTree[T]) merge(node *bPlusTreeNode[T], i int) {
+	child, right := node.children[i], node.children[i+1]
+
+	if child.isLeaf() {
+		child.keys = append(child.keys, right.keys...)
+		child.next = right.next
+	} else {
+		child.keys = append(child.keys, node.keys[i])
+		child.keys = append(child.keys, right.keys...)
+		child.children = append(child.children, right.children...)
+	}
+	child.size += right.size
+
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// rank returns the number of elements less than the given element (or less than or equal to it if
+// inclusive is set).
+func (t BPlusTree[T]) rank(elem T, inclusive bool) int {
+	count := 0
+	node := t.root
+
+	for !node.isLeaf() {
+		i := node.childIndex(elem)
+		for _, child := range node.children[:i] {
+			count += child.size
+		}
+		node = node.children[i]
+	}
+
+	i, found := slices.BinarySearch(node.keys, elem)
+	if found && inclusive {
+		i++
+	}
+
+	return count + i
+}
+
+// below returns the largest element that is less than the given element (or equal to it if
+// inclusive is set). When the leaf covering the element has no such element, the answer is the
+// largest element of the closest subtree to the left of the search path.
+func (t BPlusTree[T]) below(elem T, inclusive bool) (T, error) {
+	var left *bPlusTreeNode[T]
+	node := t.root
+
+	for !node.isLeaf() {
+		i := node.childIndex(elem)
+		if i > 0 {
+			left = node.children[i-1]
+		}
+		node = node.children[i]
+	}
+
+	i, found := slices.BinarySearch(node.keys, elem)
+	if found && inclusive {
+		return elem, nil
+	}
+	if i > 0 {
+		return node.keys[i-1], nil
+	}
+	if left != nil {
+		return left.max(), nil
+	}
+
+	var empty T
+	return empty, errors.New("no such element in the tree")
+}
+
+// above returns the smallest element that is greater than the given element (or equal to it if
+// inclusive is set), following the leaf links when the covering leaf has no such element.
+func (t BPlusTree[T]) above(elem T, inclusive bool) (T, error) {
+	leaf := t.findLeaf(elem)
+
+	i, found := slices.BinarySearch(leaf.keys, elem)
+	if found && !inclusive {
+		i++
+	}
+	if i < len(leaf.keys) {
+		return leaf.keys[i], nil
+	}
+	if leaf.next != nil {
+		return leaf.next.keys[0], nil
+	}
+
+	var empty T
+	return empty, errors.New("no such element in the tree")
+}
+
+// scan yields the elements from index i of the leaf onwards, following the leaf links, until it
+// passes the optional upper bound or yield asks to stop.
+func (t BPlusTree[T]) scan(leaf *bPlusTreeNode[T], i int, hi *T, yield func(T) bool) {
+	bPlusScan[T, *bPlusTreeNode[T]](t, leaf, i, hi, func(leaf *bPlusTreeNode[T], i int) bool {
+		return yield(leaf.keys[i])
+	})
+}
+
+// validate recursively checks the subtree rooted at the node against the optional bounds (lo
+// inclusive, hi exclusive), collecting its leaves in order, and returns the number of elements in
+// it.
+func (t BPlusTree[T]) validate(node *bPlusTreeNode[T], lo, hi *T, depth int, leafDepth *int, leaves *[]*bPlusTreeNode[T]) (int, error) {
+	if node != t.root && (len(node.keys) < t.degree-1 || len(node.keys) > t.maxKeys()) {
+		return 0, fmt.Errorf("node at depth %d holds %d keys", depth, len(node.keys))
+	}
+	for i, key := range node.keys {
+		if (i > 0 && node.keys[i-1] >= key) || (lo != nil && key < *lo) || (hi != nil && key >= *hi) {
+			return 0, fmt.Errorf("key %v is out of order", key)
+		}
+	}
+
+	size := 0
+	if node.isLeaf() {
+		if *leafDepth == -1 {
+			*leafDepth = depth
+		} else if *leafDepth != depth {
+			return 0, fmt.Errorf("leaves found at depths %d and %d", *leafDepth, depth)
+		}
+		*leaves = append(*leaves, node)
+		size = len(node.keys)
+	} else {
+		if len(node.children) != len(node.keys)+1 {
+			return 0, fmt.Errorf("node at depth %d holds %d keys but %d children", depth, len(node.keys), len(node.children))
+		}
+		for i, child := range node.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &node.keys[i-1]
+			}
+			if i < len(node.keys) {
+				childHi = &node.keys[i]
+			}
+			childSize, err := t.validate(child, childLo, childHi, depth+1, leafDepth, leaves)
+			if err != nil {
+				return 0, err
+			}
+			size += childSize
+		}
+	}
+
+	if node.size != size {
+		return 0, fmt.Errorf("node at depth %d caches size %d but holds %d elements", depth, node.size, size)
+	}
+
+	return size, nil
+}
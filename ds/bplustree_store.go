@@ -0,0 +1,74 @@
+package ds
+
+import (
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// bPlusPageStore gives the search logic shared by BPlusTree and DiskBPlusTree access to the nodes
+// of a tree, whether they are held in memory or decoded from the pages of a file. Reading a node
+// from a page can fail, so every lookup may return an error; the in-memory store never does.
+type bPlusPageStore[T constraints.Ordered, N any] interface {
+	// rootNode returns the root of the tree.
+	rootNode() (N, error)
+	// child returns the child at index i of an internal node.
+	child(node N, i int) (N, error)
+	// nextLeaf returns the leaf linked after the given leaf; ok is false for the last leaf.
+	nextLeaf(leaf N) (next N, ok bool, err error)
+	// keysOf returns the keys held by the node, in ascending order.
+	keysOf(node N) []T
+	// isLeafNode returns true if the node is a leaf; else it returns false.
+	isLeafNode(node N) bool
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// bPlusChildIndex returns the index of the child of an internal node with the given separator keys
+// whose subtree would hold the key.
+func bPlusChildIndex[T constraints.Ordered](keys []T, key T) int {
+	i, found := slices.BinarySearch(keys, key)
+	if found {
+		// separators are the smallest key of the subtree to their right
+		i++
+	}
+	return i
+}
+
+// bPlusFindLeaf returns the leaf whose range of keys covers the given key.
+func bPlusFindLeaf[T constraints.Ordered, N any](store bPlusPageStore[T, N], key T) (N, error) {
+	node, err := store.rootNode()
+	for err == nil && !store.isLeafNode(node) {
+		node, err = store.child(node, bPlusChildIndex(store.keysOf(node), key))
+	}
+	return node, err
+}
+
+// bPlusScan calls visit with the leaf and index of each key from index i of the leaf onwards,
+// following the leaf links, until it passes the optional upper bound or visit asks to stop.
+func bPlusScan[T constraints.Ordered, N any](
+	store bPlusPageStore[T, N],
+	leaf N,
+	i int,
+	hi *T,
+	visit func(leaf N, i int) bool,
+) error {
+	for {
+		keys := store.keysOf(leaf)
+		for ; i < len(keys); i++ {
+			if hi != nil && keys[i] > *hi {
+				return nil
+			}
+			if !visit(leaf, i) {
+				return nil
+			}
+		}
+
+		next, ok, err := store.nextLeaf(leaf)
+		if err != nil || !ok {
+			return err
+		}
+		leaf, i = next, 0
+	}
+}
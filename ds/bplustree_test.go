@@ -0,0 +1,80 @@
+package ds_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBPlusTreeSortedSet(t *testing.T) {
+	for _, degree := range []int{2, 3, 16} {
+		runSortedSetTests(t, func() ds.SortedSet[int] { return ds.NewBPlusTree[int](degree) })
+	}
+}
+
+func TestBPlusTreeOrderedSet(t *testing.T) {
+	for _, degree := range []int{2, 3, 16} {
+		runOrderedSetTests(t, func() orderedSet { return ds.NewBPlusTree[int](degree) })
+	}
+}
+
+func TestBPlusTreeInsert(t *testing.T) {
+	t.Run("Should stay valid when inserting sequential elements", func(t *testing.T) {
+		tree := ds.NewBPlusTree[int](2)
+		for i := range 1000 {
+			assert.Nil(t, tree.Insert(i))
+		}
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("Should reject elements that are also used as separators", func(t *testing.T) {
+		tree := ds.NewBPlusTree[int](2)
+		for i := range 20 {
+			tree.Insert(i)
+		}
+		for i := range 20 {
+			assert.NotNil(t, tree.Insert(i))
+		}
+		assert.Equal(t, 20, tree.Size())
+	})
+}
+
+func TestBPlusTreeRemove(t *testing.T) {
+	t.Run("Should not find removed elements that remain as separators", func(t *testing.T) {
+		tree := ds.NewBPlusTree[int](2)
+		for i := range 20 {
+			tree.Insert(i)
+		}
+		for i := 0; i < 20; i += 2 {
+			assert.Nil(t, tree.Remove(i))
+		}
+		for i := range 20 {
+			assert.Equal(t, i%2 == 1, tree.Contains(i))
+		}
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("Should keep the leaves linked as the tree shrinks back to empty", func(t *testing.T) {
+		tree := ds.NewBPlusTree[int](3)
+		elems := rand.New(rand.NewSource(16)).Perm(500)
+		for _, e := range elems {
+			tree.Insert(e)
+		}
+
+		remaining := slices.Sorted(slices.Values(elems))
+		for _, e := range elems {
+			assert.Nil(t, tree.Remove(e))
+			assert.Nil(t, tree.Validate())
+			i, _ := slices.BinarySearch(remaining, e)
+			remaining = slices.Delete(remaining, i, i+1)
+			assert.Equal(t, remaining, append([]int{}, slices.Collect(tree.All())...))
+		}
+		assert.Equal(t, 0, tree.Size())
+		assert.Empty(t, slices.Collect(tree.All()))
+		assert.Nil(t, tree.Insert(1))
+		assert.True(t, tree.Contains(1))
+	})
+}
@@ -1,9 +1,10 @@
 package ds
 
 import (
-	"container/list"
 	"errors"
 	"fmt"
+	"iter"
+	"slices"
 
 	"golang.org/x/exp/constraints"
 )
@@ -40,8 +41,7 @@ func (t BST[T]) Size() int {
 	return t.size
 }
 
-// FindMin returns the node with the smallest element value. If the tree is empty an error is
-// returned.
+// FindMin returns the smallest element in the tree. If the tree is empty an error is returned.
 func (t BST[T]) FindMin() (T, error) {
 	var elem T
 
@@ -54,82 +54,310 @@ func (t BST[T]) FindMin() (T, error) {
 	return smallestNode.Elem, nil
 }
 
+// FindMax returns the largest element in the tree. If the tree is empty an error is returned.
+func (t BST[T]) FindMax() (T, error) {
+	var elem T
+
+	if t.Root == nil {
+		return elem, errors.New("tree is empty")
+	}
+
+	node := t.Root
+	for node.Right != nil {
+		node = node.Right
+	}
+
+	return node.Elem, nil
+}
+
+// Find returns the node holding the given element. Equal elements are inserted into the right
+// subtree, so when the tree holds duplicates the node closest to the root is returned. If the
+// element is not found an error is returned.
+func (t BST[T]) Find(elem T) (*TreeNode[T], error) {
+	node := t.Root
+
+	for node != nil {
+		if elem < node.Elem {
+			node = node.Left
+		} else if elem > node.Elem {
+			node = node.Right
+		} else {
+			return node, nil
+		}
+	}
+
+	return nil, errors.New("element was not found")
+}
+
+// Contains returns true if the specified element is in the tree; else it returns false.
+func (t BST[T]) Contains(elem T) bool {
+	_, err := t.Find(elem)
+	return err == nil
+}
+
+// Predecessor returns the largest element in the tree that is strictly less than the given element,
+// which need not be in the tree. If there is no such element an error is returned.
+func (t BST[T]) Predecessor(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, true, false)
+}
+
+// Successor returns the smallest element in the tree that is strictly greater than the given
+// element, which need not be in the tree. If there is no such element an error is returned.
+func (t BST[T]) Successor(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, false, false)
+}
+
+// Floor returns the largest element in the tree that is less than or equal to the given element. If
+// there is no such element an error is returned.
+func (t BST[T]) Floor(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, true, true)
+}
+
+// Ceiling returns the smallest element in the tree that is greater than or equal to the given
+// element. If there is no such element an error is returned.
+func (t BST[T]) Ceiling(elem T) (T, error) {
+	return treeNeighbor(t.Root, elem, false, true)
+}
+
+// Range returns an iterator over the elements in the tree between lo and hi (inclusive) in
+// ascending order, including every copy of duplicated elements. Subtrees that fall entirely outside
+// of the range are never visited.
+func (t BST[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		inOrderRange(t.Root, lo, hi, yield)
+	}
+}
+
 // Insert adds a node to the tree and maintains the BST properties.
 func (t *BST[T]) Insert(elem T) {
 	t.Root = t.insert(t.Root, elem)
 }
 
-// Remove deletes a node from the tree and maintains the BST properties.
+// Remove deletes a node from the tree and maintains the BST properties. When the tree holds
+// duplicates of the element, the copy closest to the root is removed. If the element is not found
+// an error is returned and the tree is left unchanged.
 func (t *BST[T]) Remove(elem T) error {
 	root, err := t.remove(t.Root, elem)
 	if err != nil {
-		t.Root = root
+		return err
 	}
 
-	return err
+	t.Root = root
+
+	return nil
+}
+
+// Validate checks that the tree satisfies the BST ordering invariant (every element in a node's
+// left subtree is less than the node's element and every element in its right subtree is greater
+// than or equal to it) and that the number of reachable nodes matches Size. An error describing the
+// first violation found is returned.
+func (t BST[T]) Validate() error {
+	// bounded is a node along with the range of elements it is allowed to hold; lo is inclusive and
+	// hi is exclusive, and nil means unbounded
+	type bounded struct {
+		node *TreeNode[T]
+		lo   *T
+		hi   *T
+	}
+
+	count := 0
+	stack := []bounded{{t.Root, nil, nil}}
+
+	for len(stack) > 0 {
+		b := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if b.node == nil {
+			continue
+		}
+
+		count++
+		if count > t.size {
+			return fmt.Errorf("tree has more than the expected %d nodes (or contains a cycle)", t.size)
+		}
+
+		if b.lo != nil && b.node.Elem < *b.lo {
+			return fmt.Errorf("element %v is less than %v but is in its right subtree", b.node.Elem, *b.lo)
+		}
+		if b.hi != nil && b.node.Elem >= *b.hi {
+			return fmt.Errorf("element %v is not less than %v but is in its left subtree", b.node.Elem, *b.hi)
+		}
+
+		elem := &b.node.Elem
+		stack = append(stack, bounded{b.node.Left, b.lo, elem}, bounded{b.node.Right, elem, b.hi})
+	}
+
+	if count != t.size {
+		return fmt.Errorf("tree has %d nodes but its size is %d", count, t.size)
+	}
+
+	return nil
 }
 
 /* Collection of Traversals
 ------------------------------------------------------------------------------------------------- */
 
-func (t BST[T]) InOrder() {
-	fmt.Print("[ ")
-	inOrder(t.Root)
-	fmt.Print("]\n")
+// Traversal identifies the order in which the nodes of a tree are visited.
+type Traversal int
+
+const (
+	// TraversalInOrder visits the left subtree, then the node, then the right subtree (ascending
+	// order for a BST).
+	TraversalInOrder Traversal = iota
+	// TraversalPreOrder visits the node, then the left subtree, then the right subtree.
+	TraversalPreOrder
+	// TraversalPostOrder visits the left subtree, then the right subtree, then the node.
+	TraversalPostOrder
+	// TraversalBreadthFirst visits the nodes level by level from left to right.
+	TraversalBreadthFirst
+)
+
+// InOrder returns an iterator over the elements of the tree in in-order (ascending) order.
+func (t BST[T]) InOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		inOrder(t.Root, yield)
+	}
 }
 
-func (t BST[T]) PreOrder() {
-	fmt.Print("[ ")
-	preOrder(t.Root)
-	fmt.Print("]\n")
+// PreOrder returns an iterator over the elements of the tree in pre-order.
+func (t BST[T]) PreOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		preOrder(t.Root, yield)
+	}
 }
 
-func (t BST[T]) PostOrder() {
-	fmt.Print("[ ")
-	postOrder(t.Root)
-	fmt.Print("]\n")
+// PostOrder returns an iterator over the elements of the tree in post-order.
+func (t BST[T]) PostOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		postOrder(t.Root, yield)
+	}
 }
 
-func (t BST[T]) BreadthFirst() {
-	if t.Root == nil {
-		fmt.Println("[ ]")
-		return
+// BreadthFirst returns an iterator over the elements of the tree in level order.
+func (t BST[T]) BreadthFirst() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		breadthFirst(t.Root, yield)
+	}
+}
+
+// InOrderSlice returns the elements of the tree in in-order (ascending) order.
+func (t BST[T]) InOrderSlice() []T {
+	return slices.Collect(t.InOrder())
+}
+
+// PreOrderSlice returns the elements of the tree in pre-order.
+func (t BST[T]) PreOrderSlice() []T {
+	return slices.Collect(t.PreOrder())
+}
+
+// PostOrderSlice returns the elements of the tree in post-order.
+func (t BST[T]) PostOrderSlice() []T {
+	return slices.Collect(t.PostOrder())
+}
+
+// BreadthFirstSlice returns the elements of the tree in level order.
+func (t BST[T]) BreadthFirstSlice() []T {
+	return slices.Collect(t.BreadthFirst())
+}
+
+// Walk calls visit for each element of the tree in the given traversal order, stopping as soon as
+// visit returns false. If the traversal order is unknown no elements are visited.
+func (t BST[T]) Walk(order Traversal, visit func(T) bool) {
+	switch order {
+	case TraversalInOrder:
+		inOrder(t.Root, visit)
+	case TraversalPreOrder:
+		preOrder(t.Root, visit)
+	case TraversalPostOrder:
+		postOrder(t.Root, visit)
+	case TraversalBreadthFirst:
+		breadthFirst(t.Root, visit)
 	}
+}
 
-	q := list.New()
-	currNode := t.Root
-	q.PushBack(currNode)
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
 
-	fmt.Print("[ ")
-	for currNode != nil {
-		currNode = nil
-		l := q.Len()
-		for i := 0; i < l; i++ {
-			// Dequeue node to process
-			if n, ok := any(q.Front().Value).(*TreeNode[T]); ok {
-				currNode = n
-				q.Remove(q.Front())
+// binaryTreeNode is implemented by the nodes of the binary search trees so that searches which only
+// walk down a tree can be shared between them.
+type binaryTreeNode[T constraints.Ordered, N any] interface {
+	comparable
+	elem() T
+	left() N
+	right() N
+}
+
+func (n *TreeNode[T]) elem() T {
+	return n.Elem
+}
+
+func (n *TreeNode[T]) left() *TreeNode[T] {
+	return n.Left
+}
+
+func (n *TreeNode[T]) right() *TreeNode[T] {
+	return n.Right
+}
+
+// treeNeighbor walks from the root towards the given element, remembering the closest candidate
+// seen on the way. below selects whether the candidate must be smaller (Floor/Predecessor) or
+// larger (Ceiling/Successor) than the element, and inclusive selects whether the element itself
+// qualifies. In a BST equal elements live in right subtrees, so a node equal to the element only
+// ever rules out its left subtree (for larger candidates) or its right subtree (for smaller ones).
+func treeNeighbor[T constraints.Ordered, N binaryTreeNode[T, N]](
+	root N,
+	elem T,
+	below, inclusive bool,
+) (T, error) {
+	node, err := treeNeighborNode(root, elem, below, inclusive)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+
+	return node.elem(), nil
+}
+
+// treeNeighborNode is treeNeighbor, returning the node holding the neighbouring element rather
+// than the element itself.
+func treeNeighborNode[T constraints.Ordered, N binaryTreeNode[T, N]](
+	root N,
+	elem T,
+	below, inclusive bool,
+) (N, error) {
+	var null, candidate N
+	node := root
+
+	for node != null {
+		if inclusive && node.elem() == elem {
+			return node, nil
+		}
+
+		if below {
+			if node.elem() < elem {
+				// the node qualifies, but there may be a closer element in its right subtree
+				candidate = node
+				node = node.right()
 			} else {
-				panic("invalid type in BFS queue ")
-			}
-			// process current node
-			fmt.Print(currNode.Elem, " ")
-			// Add children to queue to be processed
-			if currNode.Left != nil {
-				// enqueue left child
-				q.PushBack(currNode.Left)
+				node = node.left()
 			}
-			if currNode.Right != nil {
-				// enqueue right child
-				q.PushBack(currNode.Right)
+		} else {
+			if node.elem() > elem {
+				// the node qualifies, but there may be a closer element in its left subtree
+				candidate = node
+				node = node.left()
+			} else {
+				node = node.right()
 			}
 		}
 	}
-	fmt.Print("]\n")
-}
 
-/* Private helper functions
-------------------------------------------------------------------------------------------------- */
+	if candidate == null {
+		return null, errors.New("no such element in the tree")
+	}
+
+	return candidate, nil
+}
 
 // insert is a recursive helper function to insert an element into the tree.
 func (t *BST[T]) insert(root *TreeNode[T], elem T) *TreeNode[T] {
@@ -151,45 +379,63 @@ func (t *BST[T]) insert(root *TreeNode[T], elem T) *TreeNode[T] {
 	return root
 }
 
-// remove is a recursive helper function to remove a node from the tree.
+// remove is a recursive helper function to remove a node from the tree. It returns the new root of
+// the subtree; if the element is not found the subtree is returned unchanged along with an error.
 func (t *BST[T]) remove(root *TreeNode[T], elem T) (*TreeNode[T], error) {
 	if root == nil {
 		// The element wasn't found in the tree
 		return root, errors.New("element was not found")
 	}
 
-	var err error = nil
-	var node *TreeNode[T]
+	var err error
 
 	if elem < root.Elem {
 		root.Left, err = t.remove(root.Left, elem)
-	} else if elem > root.Elem {
+		return root, err
+	}
+	if elem > root.Elem {
 		root.Right, err = t.remove(root.Right, elem)
-	} else {
-		// We've found the node to remove
-		if root.Left == nil {
-			// Our node has, at most, only a single child which can be promoted
-			node = root.Right
-		} else if root.Right == nil {
-			// Our node has, at most, only a single child which can be promoted
-			node = root.Left
-		} else {
-			// Our node has 2 children; we should replace it with the smallest in the right subtree
-			node = t.findMin(root.Right)
-			// Remove the node from the right subtree since we're going to add it at the root of the
-			// current subtree
-			root.Right, err = t.remove(root.Right, node.Elem)
-			node.Left = root.Left
-			node.Right = root.Right
-			// removing the minimum from the right subtree will decrement our size but we're really just
-			// moving it up in the tree to replace the original node to be removed
-			t.size++
-		}
-		t.size--
-		return node, err
+		return root, err
+	}
+
+	// We've found the node to remove
+	t.size--
+
+	if root.Left == nil {
+		// Our node has, at most, only a single child which can be promoted
+		return root.Right, nil
+	}
+	if root.Right == nil {
+		// Our node has only a single child which can be promoted
+		return root.Left, nil
 	}
 
-	return root, err
+	// Our node has 2 children; we replace it with its in-order successor (the smallest node in the
+	// right subtree). The successor is unlinked by position rather than searched for by value, which
+	// would find the wrong node if the right subtree holds duplicates of the successor's element.
+	right, successor := detachMin(root.Right)
+	successor.Left = root.Left
+	successor.Right = right
+	// clear pointers of the node being deleted to aid in GC
+	root.Left = nil
+	root.Right = nil
+
+	return successor, nil
+}
+
+// detachMin unlinks the leftmost node of the (non-empty) subtree. It returns the new root of the
+// subtree and the detached node.
+func detachMin[T constraints.Ordered](root *TreeNode[T]) (*TreeNode[T], *TreeNode[T]) {
+	if root.Left == nil {
+		rest := root.Right
+		root.Right = nil
+		return rest, root
+	}
+
+	var min *TreeNode[T]
+	root.Left, min = detachMin(root.Left)
+
+	return root, min
 }
 
 func (t BST[T]) findMin(root *TreeNode[T]) *TreeNode[T] {
@@ -204,32 +450,137 @@ func (t BST[T]) findMin(root *TreeNode[T]) *TreeNode[T] {
 	return t.findMin(root.Left)
 }
 
-func inOrder[T constraints.Ordered](root *TreeNode[T]) {
-	if root == nil {
-		return
+// The traversal helpers below are iterative (using an explicit stack or queue rather than the call
+// stack) so that traversing a deep, unbalanced tree cannot overflow the stack. Each one stops as
+// soon as yield returns false.
+
+func inOrder[T constraints.Ordered](root *TreeNode[T], yield func(T) bool) {
+	var stack []*TreeNode[T]
+	node := root
+
+	for node != nil || len(stack) > 0 {
+		// descend as far left as possible, remembering the path back up
+		for node != nil {
+			stack = append(stack, node)
+			node = node.Left
+		}
+
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !yield(node.Elem) {
+			return
+		}
+		node = node.Right
 	}
+}
+
+// inOrderRange is an in-order traversal that skips the subtrees that fall entirely outside of the
+// range [lo, hi].
+func inOrderRange[T constraints.Ordered](root *TreeNode[T], lo, hi T, yield func(T) bool) {
+	var stack []*TreeNode[T]
+	node := root
+
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			if node.Elem < lo {
+				// the node and its left subtree are all below the range
+				node = node.Right
+				continue
+			}
+			stack = append(stack, node)
+			node = node.Left
+		}
+		if len(stack) == 0 {
+			return
+		}
+
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
 
-	inOrder(root.Left)
-	fmt.Print(root.Elem, " ")
-	inOrder(root.Right)
+		if node.Elem > hi {
+			// every remaining node is at least as large
+			return
+		}
+		if !yield(node.Elem) {
+			return
+		}
+		node = node.Right
+	}
 }
 
-func preOrder[T constraints.Ordered](root *TreeNode[T]) {
+func preOrder[T constraints.Ordered](root *TreeNode[T], yield func(T) bool) {
 	if root == nil {
 		return
 	}
 
-	fmt.Print(root.Elem, " ")
-	preOrder(root.Left)
-	preOrder(root.Right)
+	stack := []*TreeNode[T]{root}
+
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !yield(node.Elem) {
+			return
+		}
+		// push the right child first so that the left subtree is visited first
+		if node.Right != nil {
+			stack = append(stack, node.Right)
+		}
+		if node.Left != nil {
+			stack = append(stack, node.Left)
+		}
+	}
+}
+
+func postOrder[T constraints.Ordered](root *TreeNode[T], yield func(T) bool) {
+	var stack []*TreeNode[T]
+	var lastVisited *TreeNode[T]
+	node := root
+
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.Left
+		}
+
+		top := stack[len(stack)-1]
+		if top.Right != nil && top.Right != lastVisited {
+			// the right subtree must be visited before the node itself
+			node = top.Right
+			continue
+		}
+
+		stack = stack[:len(stack)-1]
+		if !yield(top.Elem) {
+			return
+		}
+		lastVisited = top
+	}
 }
 
-func postOrder[T constraints.Ordered](root *TreeNode[T]) {
+func breadthFirst[T constraints.Ordered](root *TreeNode[T], yield func(T) bool) {
 	if root == nil {
 		return
 	}
 
-	postOrder(root.Left)
-	postOrder(root.Right)
-	fmt.Print(root.Elem, " ")
+	queue := []*TreeNode[T]{root}
+
+	for len(queue) > 0 {
+		// Dequeue node to process
+		node := queue[0]
+		queue[0] = nil // clear to help GC
+		queue = queue[1:]
+
+		if !yield(node.Elem) {
+			return
+		}
+		// Add children to queue to be processed
+		if node.Left != nil {
+			queue = append(queue, node.Left)
+		}
+		if node.Right != nil {
+			queue = append(queue, node.Right)
+		}
+	}
 }
@@ -1,7 +1,10 @@
 package ds_test
 
 import (
+	"iter"
+	"slices"
 	"testing"
+	"testing/quick"
 
 	"github.com/bcdxn/dsa-go/ds"
 	"github.com/stretchr/testify/assert"
@@ -231,74 +234,352 @@ func TestBstRemove(t *testing.T) {
 	})
 }
 
-func TestBstInOrder(t *testing.T) {
-	t.Run("should print the nodes in order", func(t *testing.T) {
-		tree := ds.NewBST[int]()
+// newTraversalTree returns the following tree:
+//
+//	      10
+//	   /      \
+//	  5        17
+//	 / \      /  \
+//	3   7    13   24
+//	   /       \
+//	  6         15
+func newTraversalTree() *ds.BST[int] {
+	tree := ds.NewBST[int]()
+
+	tree.Insert(10)
+	tree.Insert(5)
+	tree.Insert(7)
+	tree.Insert(17)
+	tree.Insert(3)
+	tree.Insert(24)
+	tree.Insert(13)
+	tree.Insert(15)
+	tree.Insert(6)
+
+	return tree
+}
 
-		tree.Insert(10)
-		tree.Insert(5)
-		tree.Insert(7)
-		tree.Insert(17)
-		tree.Insert(3)
-		tree.Insert(24)
-		tree.Insert(13)
-		tree.Insert(15)
-		tree.Insert(6)
+func TestBstInOrder(t *testing.T) {
+	t.Run("should iterate the nodes in order", func(t *testing.T) {
+		tree := newTraversalTree()
+		expected := []int{3, 5, 6, 7, 10, 13, 15, 17, 24}
 
-		tree.InOrder()
+		assert.Equal(t, expected, slices.Collect(tree.InOrder()))
+		assert.Equal(t, expected, tree.InOrderSlice())
 	})
 }
 
 func TestBstPreOrder(t *testing.T) {
-	t.Run("should print in pre-order", func(t *testing.T) {
-		tree := ds.NewBST[int]()
+	t.Run("should iterate in pre-order", func(t *testing.T) {
+		tree := newTraversalTree()
+		expected := []int{10, 5, 3, 7, 6, 17, 13, 15, 24}
 
-		tree.Insert(10)
-		tree.Insert(5)
-		tree.Insert(7)
-		tree.Insert(17)
-		tree.Insert(3)
-		tree.Insert(24)
-		tree.Insert(13)
-		tree.Insert(15)
-		tree.Insert(6)
-
-		tree.PreOrder()
+		assert.Equal(t, expected, slices.Collect(tree.PreOrder()))
+		assert.Equal(t, expected, tree.PreOrderSlice())
 	})
 }
 
 func TestBstPostOrder(t *testing.T) {
-	t.Run("should print in post-order", func(t *testing.T) {
+	t.Run("should iterate in post-order", func(t *testing.T) {
+		tree := newTraversalTree()
+		expected := []int{3, 6, 7, 5, 15, 13, 24, 17, 10}
+
+		assert.Equal(t, expected, slices.Collect(tree.PostOrder()))
+		assert.Equal(t, expected, tree.PostOrderSlice())
+	})
+}
+
+func TestBreadthFirst(t *testing.T) {
+	t.Run("should iterate in level order", func(t *testing.T) {
+		tree := newTraversalTree()
+		expected := []int{10, 5, 17, 3, 7, 13, 24, 6, 15}
+
+		assert.Equal(t, expected, slices.Collect(tree.BreadthFirst()))
+		assert.Equal(t, expected, tree.BreadthFirstSlice())
+	})
+}
+
+func TestBstTraversals(t *testing.T) {
+	traversals := []struct {
+		description string
+		order       ds.Traversal
+		seq         func(*ds.BST[int]) iter.Seq[int]
+		expected    []int
+	}{
+		{"in-order", ds.TraversalInOrder, (*ds.BST[int]).InOrder, []int{3, 5, 6}},
+		{"pre-order", ds.TraversalPreOrder, (*ds.BST[int]).PreOrder, []int{10, 5, 3}},
+		{"post-order", ds.TraversalPostOrder, (*ds.BST[int]).PostOrder, []int{3, 6, 7}},
+		{"breadth first", ds.TraversalBreadthFirst, (*ds.BST[int]).BreadthFirst, []int{10, 5, 17}},
+	}
+
+	for _, tr := range traversals {
+		t.Run(tr.description+" on an empty tree", func(t *testing.T) {
+			tree := ds.NewBST[int]()
+			assert.Empty(t, slices.Collect(tr.seq(tree)))
+		})
+
+		t.Run(tr.description+" iterator should stop early", func(t *testing.T) {
+			tree := newTraversalTree()
+			var seen []int
+			for e := range tr.seq(tree) {
+				seen = append(seen, e)
+				if len(seen) == 3 {
+					break
+				}
+			}
+			assert.Equal(t, tr.expected, seen)
+		})
+
+		t.Run(tr.description+" walk should stop early", func(t *testing.T) {
+			tree := newTraversalTree()
+			var seen []int
+			tree.Walk(tr.order, func(e int) bool {
+				seen = append(seen, e)
+				return len(seen) < 3
+			})
+			assert.Equal(t, tr.expected, seen)
+		})
+
+		t.Run(tr.description+" should handle a deep, degenerate tree", func(t *testing.T) {
+			const depth = 100_000
+			// build a right-leaning 'linked list' directly to avoid quadratic inserts
+			tree := ds.NewBST[int]()
+			tree.Root = &ds.TreeNode[int]{Elem: 0}
+			node := tree.Root
+			for i := 1; i < depth; i++ {
+				node.Right = &ds.TreeNode[int]{Elem: i}
+				node = node.Right
+			}
+
+			count := 0
+			for range tr.seq(tree) {
+				count++
+			}
+			assert.Equal(t, depth, count)
+		})
+	}
+}
+
+// newDuplicatesTree returns a tree holding 10, 5, 10, 15, 10, 5 and 20; equal elements are inserted
+// into the right subtree.
+func newDuplicatesTree() *ds.BST[int] {
+	tree := ds.NewBST[int]()
+	for _, e := range []int{10, 5, 10, 15, 10, 5, 20} {
+		tree.Insert(e)
+	}
+	return tree
+}
+
+func TestBstFindMax(t *testing.T) {
+	t.Run("FindMax on an empty tree", func(t *testing.T) {
 		tree := ds.NewBST[int]()
+		_, err := tree.FindMax()
+		assert.NotNil(t, err)
+	})
 
-		tree.Insert(10)
-		tree.Insert(5)
-		tree.Insert(7)
-		tree.Insert(17)
-		tree.Insert(3)
-		tree.Insert(24)
-		tree.Insert(13)
-		tree.Insert(15)
-		tree.Insert(6)
+	t.Run("should return the maximum element in the tree", func(t *testing.T) {
+		tree := newTraversalTree()
+		max, err := tree.FindMax()
+		assert.Nil(t, err)
+		assert.Equal(t, 24, max)
+	})
+}
 
-		tree.PostOrder()
+func TestBstFindContains(t *testing.T) {
+	t.Run("Find and Contains on an empty tree", func(t *testing.T) {
+		tree := ds.NewBST[int]()
+		node, err := tree.Find(10)
+		assert.Nil(t, node)
+		assert.NotNil(t, err)
+		assert.False(t, tree.Contains(10))
+	})
+
+	t.Run("Find should return the node holding the element", func(t *testing.T) {
+		tree := newTraversalTree()
+		node, err := tree.Find(7)
+		assert.Nil(t, err)
+		assert.Equal(t, 7, node.Elem)
+		assert.Equal(t, 6, node.Left.Elem)
+
+		assert.True(t, tree.Contains(15))
+		assert.False(t, tree.Contains(16))
+	})
+
+	t.Run("Find should return the shallowest duplicate", func(t *testing.T) {
+		tree := newDuplicatesTree()
+		node, err := tree.Find(10)
+		assert.Nil(t, err)
+		assert.Same(t, tree.Root, node)
 	})
 }
 
-func TestBreadthFirst(t *testing.T) {
-	t.Run("should print in post-order", func(t *testing.T) {
+func TestBstNeighbors(t *testing.T) {
+	tree := newDuplicatesTree()
+
+	tests := []struct {
+		description string
+		query       func(int) (int, error)
+		elem        int
+		expected    int
+		found       bool
+	}{
+		{"Predecessor of a duplicated element", tree.Predecessor, 10, 5, true},
+		{"Predecessor of a missing element", tree.Predecessor, 12, 10, true},
+		{"Predecessor of the minimum", tree.Predecessor, 5, 0, false},
+		{"Successor of a duplicated element", tree.Successor, 10, 15, true},
+		{"Successor of a duplicated minimum", tree.Successor, 5, 10, true},
+		{"Successor of a missing element", tree.Successor, 16, 20, true},
+		{"Successor of the maximum", tree.Successor, 20, 0, false},
+		{"Floor of a present element", tree.Floor, 15, 15, true},
+		{"Floor between elements", tree.Floor, 14, 10, true},
+		{"Floor below the minimum", tree.Floor, 4, 0, false},
+		{"Ceiling of a present element", tree.Ceiling, 5, 5, true},
+		{"Ceiling between elements", tree.Ceiling, 11, 15, true},
+		{"Ceiling above the maximum", tree.Ceiling, 21, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			elem, err := test.query(test.elem)
+			if test.found {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, elem)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestBstRange(t *testing.T) {
+	t.Run("Range on an empty tree", func(t *testing.T) {
 		tree := ds.NewBST[int]()
+		assert.Empty(t, slices.Collect(tree.Range(0, 10)))
+	})
+
+	t.Run("Range should include every duplicate within the bounds", func(t *testing.T) {
+		tree := newDuplicatesTree()
+		assert.Equal(t, []int{5, 5, 10, 10, 10}, slices.Collect(tree.Range(5, 10)))
+		assert.Equal(t, []int{10, 10, 10, 15}, slices.Collect(tree.Range(6, 19)))
+		assert.Empty(t, slices.Collect(tree.Range(11, 14)))
+		assert.Empty(t, slices.Collect(tree.Range(15, 10)))
+	})
 
+	t.Run("Range should stop when the consumer stops", func(t *testing.T) {
+		tree := newTraversalTree()
+		var seen []int
+		for e := range tree.Range(5, 20) {
+			seen = append(seen, e)
+			if len(seen) == 2 {
+				break
+			}
+		}
+		assert.Equal(t, []int{5, 6}, seen)
+	})
+}
+
+func TestBstRemoveRoot(t *testing.T) {
+	t.Run("removing the only node should empty the tree", func(t *testing.T) {
+		tree := ds.NewBST[int]()
 		tree.Insert(10)
-		tree.Insert(5)
-		tree.Insert(7)
-		tree.Insert(17)
-		tree.Insert(3)
-		tree.Insert(24)
-		tree.Insert(13)
-		tree.Insert(15)
-		tree.Insert(6)
+		assert.Nil(t, tree.Remove(10))
+		assert.Nil(t, tree.Root)
+		assert.False(t, tree.Contains(10))
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("removing a root with two children should promote its successor", func(t *testing.T) {
+		tree := newTraversalTree()
+		assert.Nil(t, tree.Remove(10))
+		assert.Equal(t, 13, tree.Root.Elem)
+		assert.Equal(t, 5, tree.Root.Left.Elem)
+		assert.Equal(t, 17, tree.Root.Right.Elem)
+		assert.Equal(t, 15, tree.Root.Right.Left.Elem)
+		assert.False(t, tree.Contains(10))
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("a failed removal should leave the tree unchanged", func(t *testing.T) {
+		tree := newTraversalTree()
+		root := tree.Root
+		assert.NotNil(t, tree.Remove(100))
+		assert.Same(t, root, tree.Root)
+		assert.Equal(t, 9, tree.Size())
+	})
+
+	t.Run("removing a node whose successor is duplicated", func(t *testing.T) {
+		tree := ds.NewBST[int]()
+		// 12 is the successor of 10 and another 12 sits below it in the right subtree
+		for _, e := range []int{10, 5, 20, 12, 12, 25} {
+			tree.Insert(e)
+		}
+		assert.Nil(t, tree.Remove(10))
+		assert.Nil(t, tree.Validate())
+		assert.Equal(t, []int{5, 12, 12, 20, 25}, tree.InOrderSlice())
+	})
+}
+
+func TestBstValidate(t *testing.T) {
+	t.Run("an empty tree is valid", func(t *testing.T) {
+		assert.Nil(t, ds.NewBST[int]().Validate())
+	})
 
-		tree.BreadthFirst()
+	t.Run("should detect an element in the wrong subtree", func(t *testing.T) {
+		tree := newTraversalTree()
+		assert.Nil(t, tree.Validate())
+		// 6 sits in the right subtree of 5, so it must not drop below 5
+		tree.Root.Left.Right.Left.Elem = 4
+		assert.NotNil(t, tree.Validate())
 	})
+
+	t.Run("should detect a duplicate in a left subtree", func(t *testing.T) {
+		tree := newTraversalTree()
+		tree.Root.Left.Right.Elem = 10
+		assert.NotNil(t, tree.Validate())
+	})
+
+	t.Run("should detect a size mismatch", func(t *testing.T) {
+		tree := newTraversalTree()
+		tree.Root.Right.Right = nil
+		assert.NotNil(t, tree.Validate())
+	})
+
+	t.Run("should detect a cycle", func(t *testing.T) {
+		tree := newTraversalTree()
+		tree.Root.Right.Right.Right = tree.Root
+		assert.NotNil(t, tree.Validate())
+	})
+}
+
+func TestBstRandomOperations(t *testing.T) {
+	// Each op encodes an operation in its top bit (insert or remove) and an element in its low bits;
+	// the small element range guarantees plenty of duplicates.
+	property := func(ops []uint16) bool {
+		tree := ds.NewBST[int]()
+		var oracle []int
+
+		for _, op := range ops {
+			elem := int(op & 31)
+			i, found := slices.BinarySearch(oracle, elem)
+
+			if op&0x8000 == 0 {
+				tree.Insert(elem)
+				oracle = slices.Insert(oracle, i, elem)
+			} else if err := tree.Remove(elem); found != (err == nil) {
+				return false
+			} else if found {
+				oracle = slices.Delete(oracle, i, i+1)
+			}
+
+			if tree.Validate() != nil || tree.Size() != len(oracle) {
+				return false
+			}
+		}
+
+		return slices.Equal(oracle, tree.InOrderSlice())
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
 }
@@ -0,0 +1,601 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MinBTreeDegree is the smallest minimum degree a B-Tree or B+Tree can be created with.
+const MinBTreeDegree = 2
+
+// bTreeNode is a single node in a B-Tree. Every node stores its keys in sorted order; internal nodes
+// also store len(keys)+1 children, where children[i] holds the keys between keys[i-1] and keys[i].
+type bTreeNode[T constraints.Ordered] struct {
+	keys []T
+	// children is nil for leaf nodes
+	children []*bTreeNode[T]
+	// size is the number of keys in the subtree rooted at this node (including its own keys)
+	size int
+}
+
+// BTree is an implementation of a B-Tree: a balanced search tree whose nodes each hold up to
+// 2*degree-1 keys in a contiguous slice. Storing many keys per node keeps the tree shallow and makes
+// lookups far more cache-friendly than the pointer-per-element BST and AVLTree.
+type BTree[T constraints.Ordered] struct {
+	root   *bTreeNode[T]
+	degree int
+}
+
+// NewBTree returns a pointer to a new B-Tree with the given minimum degree. Every node other than
+// the root holds between degree-1 and 2*degree-1 keys. Degrees smaller than MinBTreeDegree are
+// raised to MinBTreeDegree.
+func NewBTree[T constraints.Ordered](degree int) *BTree[T] {
+	degree = max(degree, MinBTreeDegree)
+
+	return &BTree[T]{
+		root:   newBTreeNode[T](degree, true),
+		degree: degree,
+	}
+}
+
+// Size returns the number of elements in the tree.
+func (t BTree[T]) Size() int {
+	return t.root.size
+}
+
+// Contains returns true if the specified element is in the tree; else it returns false.
+func (t BTree[T]) Contains(elem T) bool {
+	node := t.root
+	for {
+		i, found := slices.BinarySearch(node.keys, elem)
+		if found {
+			return true
+		}
+		if node.isLeaf() {
+			return false
+		}
+		node = node.children[i]
+	}
+}
+
+// Insert adds an element into the tree; if the element is already in the tree an error is returned.
+// Full nodes are split on the way down so that the insertion never has to backtrack.
+func (t *BTree[T]) Insert(elem T) error {
+	if len(t.root.keys) == t.maxKeys() {
+		// grow the tree upwards by splitting the full root
+		root := newBTreeNode[T](t.degree, false)
+		root.children = append(root.children, t.root)
+		root.size = t.root.size
+		t.splitChild(root, 0)
+		t.root = root
+	}
+
+	var path []*bTreeNode[T]
+	node := t.root
+	for {
+		i, found := slices.BinarySearch(node.keys, elem)
+		if found {
+			return errors.New("cannot insert a duplicate element")
+		}
+		path = append(path, node)
+
+		if node.isLeaf() {
+			node.keys = slices.Insert(node.keys, i, elem)
+			break
+		}
+
+		if len(node.children[i].keys) == t.maxKeys() {
+			t.splitChild(node, i)
+			if elem == node.keys[i] {
+				return errors.New("cannot insert a duplicate element")
+			} else if elem > node.keys[i] {
+				i++
+			}
+		}
+		node = node.children[i]
+	}
+
+	// the element was added beneath every node on the path
+	for _, node := range path {
+		node.size++
+	}
+
+	return nil
+}
+
+// Remove removes an element from the tree; if the element is not in the tree an error is returned.
+// Nodes on the way down are topped up to at least degree keys so that the removal never has to
+// backtrack.
+func (t *BTree[T]) Remove(elem T) error {
+	if !t.Contains(elem) {
+		return errors.New("element not found in the tree")
+	}
+
+	t.remove(t.root, elem)
+	if len(t.root.keys) == 0 && !t.root.isLeaf() {
+		// shrink the tree by dropping the empty root
+		t.root = t.root.children[0]
+	}
+
+	return nil
+}
+
+// Min returns the smallest element in the tree; if the tree is empty an error is returned.
+func (t BTree[T]) Min() (T, error) {
+	if t.Size() == 0 {
+		var empty T
+		return empty, errors.New("tree is empty")
+	}
+
+	return t.root.min(), nil
+}
+
+// Max returns the largest element in the tree; if the tree is empty an error is returned.
+func (t BTree[T]) Max() (T, error) {
+	if t.Size() == 0 {
+		var empty T
+		return empty, errors.New("tree is empty")
+	}
+
+	return t.root.max(), nil
+}
+
+// Floor returns the largest element in the tree that is less than or equal to the given element; if
+// there is no such element an error is returned.
+func (t BTree[T]) Floor(elem T) (T, error) {
+	return t.neighbor(elem, true, true)
+}
+
+// Ceiling returns the smallest element in the tree that is greater than or equal to the given
+// element; if there is no such element an error is returned.
+func (t BTree[T]) Ceiling(elem T) (T, error) {
+	return t.neighbor(elem, false, true)
+}
+
+// Lower returns the largest element in the tree that is strictly less than the given element; if
+// there is no such element an error is returned.
+func (t BTree[T]) Lower(elem T) (T, error) {
+	return t.neighbor(elem, true, false)
+}
+
+// Higher returns the smallest element in the tree that is strictly greater than the given element;
+// if there is no such element an error is returned.
+func (t BTree[T]) Higher(elem T) (T, error) {
+	return t.neighbor(elem, false, false)
+}
+
+// Select returns the element with the given 0-based rank, i.e. the k-th smallest element in the
+// tree (Select(0) returns the minimum). If k is out of range an error is returned. Select runs in
+// O(degree * log n) time.
+func (t BTree[T]) Select(k int) (T, error) {
+	if k < 0 || k >= t.Size() {
+		var empty T
+		return empty, errors.New("rank out of range")
+	}
+
+	node := t.root
+	for !node.isLeaf() {
+		i := 0
+		for ; k >= node.children[i].size; i++ {
+			// skip over the child and the key that follows it
+			k -= node.children[i].size
+			if k == 0 {
+				return node.keys[i], nil
+			}
+			k--
+		}
+		node = node.children[i]
+	}
+
+	return node.keys[k], nil
+}
+
+// Rank returns the number of elements in the tree that are strictly less than the given element,
+// which need not be in the tree. When the element is in the tree this is its 0-based position in
+// sorted order.
+func (t BTree[T]) Rank(elem T) int {
+	return t.rank(elem, false)
+}
+
+// CountRange returns the number of elements in the tree between lo and hi (inclusive).
+func (t BTree[T]) CountRange(lo, hi T) int {
+	if hi < lo {
+		return 0
+	}
+
+	return t.rank(hi, true) - t.rank(lo, false)
+}
+
+// All returns an iterator over every element in the tree in ascending order. The tree must not be
+// modified during iteration.
+func (t BTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.ascend(nil, nil, yield)
+	}
+}
+
+// Range returns an iterator over the elements in the tree between lo and hi (inclusive) in
+// ascending order. The tree must not be modified during iteration.
+func (t BTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.ascend(&lo, &hi, yield)
+	}
+}
+
+// Validate checks that the tree satisfies the B-Tree properties: keys are sorted within and across
+// nodes, every node other than the root holds between degree-1 and 2*degree-1 keys, every leaf is at
+// the same depth and every cached subtree size is correct. The first violation found is returned as
+// an error.
+func (t BTree[T]) Validate() error {
+	leafDepth := -1
+	_, err := t.validate(t.root, nil, nil, 0, &leafDepth)
+	return err
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// newBTreeNode returns an empty node with room for a full complement of keys (and children).
+func newBTreeNode[T constraints.Ordered](degree int, leaf bool) *bTreeNode[T] {
+	node := &bTreeNode[T]{
+		keys: make([]T, 0, 2*degree-1),
+	}
+	if !leaf {
+		node.children = make([]*bTreeNode[T], 0, 2*degree)
+	}
+
+	return node
+}
+
+func (n *bTreeNode[T]) isLeaf() bool {
+	return n.children == nil
+}
+
+// min returns the smallest key in the subtree rooted at the (non-empty) node.
+func (n *bTreeNode[T]) min() T {
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+	return n.keys[0]
+}
+
+// max returns the largest key in the subtree rooted at the (non-empty) node.
+func (n *bTreeNode[T]) max() T {
+	for !n.isLeaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.keys[len(n.keys)-1]
+}
+
+// bTreeChildSize returns the size of the child subtree at index i of the node, which may be a leaf.
+func bTreeChildSize[T constraints.Ordered](n *bTreeNode[T], i int) int {
+	if n.isLeaf() {
+		return 0
+	}
+	return n.children[i].size
+}
+
+func (t BTree[T]) maxKeys() int {
+	return 2*t.degree - 1
+}
+
+// splitChild splits the full child at index i of the parent around its median key, which moves up
+// into the parent. The parent must not be full.
+func (t *BTree[T]) splitChild(parent *bTreeNode[T], i int) {
+	child := parent.children[i]
+	mid := t.degree - 1
+	median := child.keys[mid]
+
+	sibling := newBTreeNode[T](t.degree, child.isLeaf())
+	sibling.keys = append(sibling.keys, child.keys[mid+1:]...)
+	sibling.size = len(sibling.keys)
+	if !child.isLeaf() {
+		sibling.children = append(sibling.children, child.children[mid+1:]...)
+		for _, c := range sibling.children {
+			sibling.size += c.size
+		}
+		clear(child.children[mid+1:]) // clear to help GC
+		child.children = child.children[:mid+1]
+	}
+	clear(child.keys[mid:])
+	child.keys = child.keys[:mid]
+	child.size -= sibling.size + 1
+
+	parent.keys = slices.Insert(parent.keys, i, median)
+	parent.children = slices.Insert(parent.children, i+1, sibling)
+}
+
+// remove deletes the element, which must be in the subtree rooted at the node. Every node that
+// remove is called on (other than the root) holds at least degree keys.
+func (t *BTree[T]) remove(node *bTreeNode[T], elem T) {
+	i, found := slices.BinarySearch(node.keys, elem)
+	node.size--
+
+	if node.isLeaf() {
+		node.keys = slices.Delete(node.keys, i, i+1)
+		return
+	}
+
+	if found {
+		if len(node.children[i].keys) >= t.degree {
+			// replace the element with its predecessor and remove that instead
+			pred := node.children[i].max()
+			node.keys[i] = pred
+			t.remove(node.children[i], pred)
+		} else if len(node.children[i+1].keys) >= t.degree {
+			// replace the element with its successor and remove that instead
+			succ := node.children[i+1].min()
+			node.keys[i] = succ
+			t.remove(node.children[i+1], succ)
+		} else {
+			// both neighbouring children are minimal; merge them around the element and recurse
+			t.merge(node, i)
+			t.remove(node.children[i], elem)
+		}
+		return
+	}
+
+	if len(node.children[i].keys) < t.degree {
+		i = t.fill(node, i)
+	}
+	t.remove(node.children[i], elem)
+}
+
+// fill tops up the minimal child at index i of the node by borrowing a key from a sibling, or by
+// merging it with a sibling when both are minimal. It returns the index of the child that now
+// covers the original child's keys.
+func (t *BTree[T]) fill(node *bTreeNode[T], i int) int {
+	if i > 0 && len(node.children[i-1].keys) >= t.degree {
+		t.borrowFromLeft(node, i)
+		return i
+	}
+	if i < len(node.keys) && len(node.children[i+1].keys) >= t.degree {
+		t.borrowFromRight(node, i)
+		return i
+	}
+	if i < len(node.keys) {
+		t.merge(node, i)
+		return i
+	}
+	t.merge(node, i-1)
+	return i - 1
+}
+
+// borrowFromLeft rotates the last key of the left sibling up into the node and the separating key
+// down into the child at index i.
+func (t *BTree[T]) borrowFromLeft(node *bTreeNode[T], i int) {
+	child, left := node.children[i], node.children[i-1]
+
+	child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+	node.keys[i-1] = left.keys[len(left.keys)-1]
+	left.keys = left.keys[:len(left.keys)-1]
+	moved := 1
+
+	if !child.isLeaf() {
+		last := left.children[len(left.children)-1]
+		left.children[len(left.children)-1] = nil // clear to help GC
+		left.children = left.children[:len(left.children)-1]
+		child.children = slices.Insert(child.children, 0, last)
+		moved += last.size
+	}
+
+	child.size += moved
+	left.size -= moved
+}
+
+// borrowFromRight rotates the first key of the right sibling up into the node and the separating
+// key down into the child at index i.
+func (t *BTree[T]) borrowFromRight(node *bTreeNode[T], i int) {
+	child, right := node.children[i], node.children[i+1]
+
+	child.keys = append(child.keys, node.keys[i])
+	node.keys[i] = right.keys[0]
+	right.keys = slices.Delete(right.keys, 0, 1)
+	moved := 1
+
+	if !child.isLeaf() {
+		first := right.children[0]
+		right.children = slices.Delete(right.children, 0, 1)
+		child.children = append(child.children, first)
+		moved += first.size
+	}
+
+	child.size += moved
+	right.size -= moved
+}
+
+// merge folds the key at index i of the node and the child to its right into the child to its left.
+func (t *BTree[T]) merge(node *bTreeNode[T], i int) {
+	child, right := node.children[i], node.children[i+1]
+
+	child.keys = append(child.keys, node.keys[i])
+	child.keys = append(child.keys, right.keys...)
+	if !child.isLeaf() {
+		child.children = append(child.children, right.children...)
+	}
+	child.size += right.size + 1
+
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// rank returns the number of elements less than the given element (or less than or equal to it if
+// inclusive is set).
+func (t BTree[T]) rank(elem T, inclusive bool) int {
+	count := 0
+	node := t.root
+
+	for {
+		i, found := slices.BinarySearch(node.keys, elem)
+		// the i keys before the element are counted along with every child to their left
+		count += i
+		for j := range i {
+			count += bTreeChildSize(node, j)
+		}
+		if found {
+			// everything in the child immediately to the left is also smaller
+			count += bTreeChildSize(node, i)
+			if inclusive {
+				count++
+			}
+			return count
+		}
+		if node.isLeaf() {
+			return count
+		}
+		node = node.children[i]
+	}
+}
+
+// neighbor walks from the root towards the given element, remembering the closest candidate seen on
+// the way. below selects whether the candidate must be smaller (Floor/Lower) or larger
+// (Ceiling/Higher) than the element, and inclusive selects whether the element itself qualifies.
+func (t BTree[T]) neighbor(elem T, below bool, inclusive bool) (T, error) {
+	var candidate T
+	hasCandidate := false
+	node := t.root
+
+	for {
+		i, found := slices.BinarySearch(node.keys, elem)
+		if found && inclusive {
+			return elem, nil
+		}
+
+		// the keys either side of the search position bound the element within this node
+		if below {
+			if i > 0 {
+				candidate, hasCandidate = node.keys[i-1], true
+			}
+		} else {
+			j := i
+			if found {
+				j++
+			}
+			if j < len(node.keys) {
+				candidate, hasCandidate = node.keys[j], true
+			}
+		}
+
+		if node.isLeaf() {
+			break
+		}
+		if found {
+			// the closest elements are the extremes of the children on either side of the element
+			if below {
+				return node.children[i].max(), nil
+			}
+			return node.children[i+1].min(), nil
+		}
+		node = node.children[i]
+	}
+
+	if !hasCandidate {
+		var empty T
+		return empty, errors.New("no such element in the tree")
+	}
+
+	return candidate, nil
+}
+
+// bTreeCursor records the next key to visit in a node during an in-order traversal.
+type bTreeCursor[T constraints.Ordered] struct {
+	node *bTreeNode[T]
+	i    int
+}
+
+// ascend performs an iterative in-order traversal of the tree, yielding the elements between the
+// optional lo and hi bounds until yield asks to stop.
+func (t BTree[T]) ascend(lo, hi *T, yield func(T) bool) {
+	var stack []bTreeCursor[T]
+
+	// descend to the first key that is not below the lower bound
+	for node := t.root; node != nil; {
+		i, found := 0, false
+		if lo != nil {
+			i, found = slices.BinarySearch(node.keys, *lo)
+		}
+		stack = append(stack, bTreeCursor[T]{node, i})
+		if found || node.isLeaf() {
+			break
+		}
+		node = node.children[i]
+	}
+
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i >= len(top.node.keys) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		node, key := top.node, top.node.keys[top.i]
+		top.i++
+		if hi != nil && key > *hi {
+			return
+		}
+		if !yield(key) {
+			return
+		}
+
+		if !node.isLeaf() {
+			// visit the subtree between this key and the next before moving on
+			for child := node.children[top.i]; child != nil; {
+				stack = append(stack, bTreeCursor[T]{child, 0})
+				if child.isLeaf() {
+					break
+				}
+				child = child.children[0]
+			}
+		}
+	}
+}
+
+// validate recursively checks the subtree rooted at the node against the optional exclusive bounds
+// and returns the number of keys in it.
+func (t BTree[T]) validate(node *bTreeNode[T], lo, hi *T, depth int, leafDepth *int) (int, error) {
+	if node != t.root && (len(node.keys) < t.degree-1 || len(node.keys) > t.maxKeys()) {
+		return 0, fmt.Errorf("node at depth %d holds %d keys", depth, len(node.keys))
+	}
+	for i, key := range node.keys {
+		if (i > 0 && node.keys[i-1] >= key) || (lo != nil && key <= *lo) || (hi != nil && key >= *hi) {
+			return 0, fmt.Errorf("key %v is out of order", key)
+		}
+	}
+
+	size := len(node.keys)
+	if node.isLeaf() {
+		if *leafDepth == -1 {
+			*leafDepth = depth
+		} else if *leafDepth != depth {
+			return 0, fmt.Errorf("leaves found at depths %d and %d", *leafDepth, depth)
+		}
+	} else {
+		if len(node.children) != len(node.keys)+1 {
+			return 0, fmt.Errorf("node at depth %d holds %d keys but %d children", depth, len(node.keys), len(node.children))
+		}
+		for i, child := range node.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &node.keys[i-1]
+			}
+			if i < len(node.keys) {
+				childHi = &node.keys[i]
+			}
+			childSize, err := t.validate(child, childLo, childHi, depth+1, leafDepth)
+			if err != nil {
+				return 0, err
+			}
+			size += childSize
+		}
+	}
+
+	if node.size != size {
+		return 0, fmt.Errorf("node at depth %d caches size %d but holds %d keys", depth, node.size, size)
+	}
+
+	return size, nil
+}
@@ -0,0 +1,100 @@
+package ds_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+)
+
+const orderedSetBenchmarkSize = 1_000_000
+
+var orderedSetBenchmarks = []struct {
+	name   string
+	newSet func() orderedSet
+}{
+	{"AVLTree", func() orderedSet { return ds.NewAVLTree[int]() }},
+	{"BTree/degree=16", func() orderedSet { return ds.NewBTree[int](16) }},
+	{"BTree/degree=64", func() orderedSet { return ds.NewBTree[int](64) }},
+	{"BPlusTree/degree=16", func() orderedSet { return ds.NewBPlusTree[int](16) }},
+	{"BPlusTree/degree=64", func() orderedSet { return ds.NewBPlusTree[int](64) }},
+}
+
+// newBenchmarkOrderedSets returns one set per implementation, each holding every even number below
+// twice the benchmark size so that odd numbers can be used to look up missing elements.
+func newBenchmarkOrderedSets() []orderedSet {
+	elems := rand.New(rand.NewSource(1)).Perm(orderedSetBenchmarkSize)
+	sets := make([]orderedSet, len(orderedSetBenchmarks))
+	for i, bm := range orderedSetBenchmarks {
+		sets[i] = bm.newSet()
+		for _, e := range elems {
+			sets[i].Insert(2 * e)
+		}
+	}
+	return sets
+}
+
+func BenchmarkOrderedSetInsert1M(b *testing.B) {
+	elems := rand.New(rand.NewSource(1)).Perm(orderedSetBenchmarkSize)
+
+	for _, bm := range orderedSetBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for range b.N {
+				s := bm.newSet()
+				for _, e := range elems {
+					s.Insert(e)
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(elems)), "ns/insert")
+		})
+	}
+}
+
+func BenchmarkOrderedSetContains1M(b *testing.B) {
+	sets := newBenchmarkOrderedSets()
+	keys := rand.New(rand.NewSource(2)).Perm(2 * orderedSetBenchmarkSize)
+
+	for i, bm := range orderedSetBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for n := range b.N {
+				sets[i].Contains(keys[n%len(keys)])
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSetRangeScan1M(b *testing.B) {
+	sets := newBenchmarkOrderedSets()
+	starts := rand.New(rand.NewSource(3)).Perm(2 * orderedSetBenchmarkSize)
+
+	for i, bm := range orderedSetBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for n := range b.N {
+				// each scan visits 1000 elements
+				lo := starts[n%len(starts)]
+				for range sets[i].Range(lo, lo+2000) {
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSetRemove1M(b *testing.B) {
+	elems := rand.New(rand.NewSource(1)).Perm(orderedSetBenchmarkSize)
+
+	for _, bm := range orderedSetBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				s := bm.newSet()
+				for _, e := range elems {
+					s.Insert(e)
+				}
+				b.StartTimer()
+				for _, e := range elems {
+					s.Remove(e)
+				}
+			}
+		})
+	}
+}
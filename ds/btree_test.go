@@ -0,0 +1,217 @@
+package ds_test
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// orderedSet is the set of ordered queries shared by AVLTree, BTree and BPlusTree.
+type orderedSet interface {
+	ds.SortedSet[int]
+	Min() (int, error)
+	Max() (int, error)
+	Floor(int) (int, error)
+	Ceiling(int) (int, error)
+	Lower(int) (int, error)
+	Higher(int) (int, error)
+	Select(int) (int, error)
+	Rank(int) int
+	CountRange(lo, hi int) int
+	All() iter.Seq[int]
+	Range(lo, hi int) iter.Seq[int]
+}
+
+// validateOrderedSet checks the structural invariants of implementations that can validate
+// themselves.
+func validateOrderedSet(t *testing.T, s orderedSet) {
+	if v, ok := s.(interface{ Validate() error }); ok {
+		assert.Nil(t, v.Validate())
+	}
+}
+
+// runOrderedSetTests compares every ordered query against a sorted slice after a series of random
+// insertions and removals.
+func runOrderedSetTests(t *testing.T, newSet func() orderedSet) {
+	t.Run("Should report errors on an empty set", func(t *testing.T) {
+		s := newSet()
+		_, err := s.Min()
+		assert.NotNil(t, err)
+		_, err = s.Max()
+		assert.NotNil(t, err)
+		_, err = s.Floor(1)
+		assert.NotNil(t, err)
+		_, err = s.Higher(1)
+		assert.NotNil(t, err)
+		_, err = s.Select(0)
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, s.Rank(1))
+		assert.Empty(t, slices.Collect(s.All()))
+		validateOrderedSet(t, s)
+	})
+
+	t.Run("Should answer ordered queries like a sorted slice", func(t *testing.T) {
+		r := rand.New(rand.NewSource(16))
+		s := newSet()
+		var oracle []int
+
+		for round := range 10 {
+			for range 1000 {
+				elem := r.Intn(2000)
+				i, found := slices.BinarySearch(oracle, elem)
+				// grow the set in the early rounds and shrink it in the later ones
+				insert := r.Intn(10) < 7
+				if round >= 5 {
+					insert = !insert
+				}
+				if insert {
+					assert.Equal(t, found, s.Insert(elem) != nil)
+					if !found {
+						oracle = slices.Insert(oracle, i, elem)
+					}
+				} else {
+					assert.Equal(t, found, s.Remove(elem) == nil)
+					if found {
+						oracle = slices.Delete(oracle, i, i+1)
+					}
+				}
+			}
+
+			validateOrderedSet(t, s)
+			assert.Equal(t, len(oracle), s.Size())
+			assert.Equal(t, oracle, slices.Collect(s.All()))
+			for k := range oracle {
+				e, err := s.Select(k)
+				assert.Nil(t, err)
+				assert.Equal(t, oracle[k], e)
+			}
+
+			for elem := -1; elem <= 2000; elem += 7 {
+				i, found := slices.BinarySearch(oracle, elem)
+				assert.Equal(t, i, s.Rank(elem))
+
+				floor, err := s.Floor(elem)
+				if found {
+					assert.Equal(t, elem, floor)
+				} else if i > 0 {
+					assert.Equal(t, oracle[i-1], floor)
+				} else {
+					assert.NotNil(t, err)
+				}
+				lower, err := s.Lower(elem)
+				if i > 0 {
+					assert.Equal(t, oracle[i-1], lower)
+				} else {
+					assert.NotNil(t, err)
+				}
+				ceiling, err := s.Ceiling(elem)
+				if i < len(oracle) {
+					assert.Equal(t, oracle[i], ceiling)
+				} else {
+					assert.NotNil(t, err)
+				}
+				j := i
+				if found {
+					j++
+				}
+				higher, err := s.Higher(elem)
+				if j < len(oracle) {
+					assert.Equal(t, oracle[j], higher)
+				} else {
+					assert.NotNil(t, err)
+				}
+
+				hi := elem + r.Intn(100)
+				k, _ := slices.BinarySearch(oracle, hi+1)
+				assert.Equal(t, k-i, s.CountRange(elem, hi))
+				assert.Equal(t, append([]int{}, oracle[i:k]...), append([]int{}, slices.Collect(s.Range(elem, hi))...))
+			}
+		}
+	})
+
+	t.Run("Should stop iterating when the consumer stops", func(t *testing.T) {
+		s := newSet()
+		for i := range 100 {
+			s.Insert(i)
+		}
+
+		var seen []int
+		for e := range s.Range(10, 90) {
+			seen = append(seen, e)
+			if len(seen) == 3 {
+				break
+			}
+		}
+		assert.Equal(t, []int{10, 11, 12}, seen)
+	})
+}
+
+func TestAVLTreeOrderedSet(t *testing.T) {
+	runOrderedSetTests(t, func() orderedSet { return ds.NewAVLTree[int]() })
+}
+
+func TestBTreeSortedSet(t *testing.T) {
+	for _, degree := range []int{2, 3, 16} {
+		runSortedSetTests(t, func() ds.SortedSet[int] { return ds.NewBTree[int](degree) })
+	}
+}
+
+func TestBTreeOrderedSet(t *testing.T) {
+	for _, degree := range []int{2, 3, 16} {
+		runOrderedSetTests(t, func() orderedSet { return ds.NewBTree[int](degree) })
+	}
+}
+
+func TestNewBTree(t *testing.T) {
+	t.Run("Should raise degrees below the minimum", func(t *testing.T) {
+		tree := ds.NewBTree[int](0)
+		for i := range 100 {
+			assert.Nil(t, tree.Insert(i))
+		}
+		assert.Nil(t, tree.Validate())
+		assert.Equal(t, 100, tree.Size())
+	})
+}
+
+func TestBTreeInsert(t *testing.T) {
+	t.Run("Should stay valid when inserting sequential elements", func(t *testing.T) {
+		tree := ds.NewBTree[int](2)
+		for i := range 1000 {
+			assert.Nil(t, tree.Insert(i))
+		}
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("Should reject a duplicate that is promoted by a split", func(t *testing.T) {
+		tree := ds.NewBTree[int](2)
+		for _, e := range []int{1, 2, 3, 4, 5} {
+			tree.Insert(e)
+		}
+		for _, e := range []int{1, 2, 3, 4, 5} {
+			assert.NotNil(t, tree.Insert(e))
+		}
+		assert.Equal(t, 5, tree.Size())
+		assert.Nil(t, tree.Validate())
+	})
+}
+
+func TestBTreeRemove(t *testing.T) {
+	t.Run("Should shrink back to an empty tree", func(t *testing.T) {
+		tree := ds.NewBTree[int](2)
+		elems := rand.New(rand.NewSource(16)).Perm(500)
+		for _, e := range elems {
+			tree.Insert(e)
+		}
+		for _, e := range elems {
+			assert.Nil(t, tree.Remove(e))
+			assert.Nil(t, tree.Validate())
+		}
+		assert.Equal(t, 0, tree.Size())
+		assert.Nil(t, tree.Insert(1))
+		assert.True(t, tree.Contains(1))
+	})
+}
@@ -0,0 +1,155 @@
+package ds
+
+import "sync"
+
+const defaultConcurrentHashMapShards = 32
+
+// concurrentHashMapShard is a HashMap guarded by its own lock so that goroutines working with keys
+// in different shards never contend with one another.
+type concurrentHashMapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  *HashMap[K, V]
+}
+
+// ConcurrentHashMap is a key/value hash table that is safe for concurrent use by multiple
+// goroutines. Keys are spread across a fixed number of independently locked HashMap shards, so
+// readers never block one another and writers only block goroutines using the same shard.
+type ConcurrentHashMap[K comparable, V any] struct {
+	shards []*concurrentHashMapShard[K, V]
+	// shift is used to select a shard from the top bits of a mixed hash
+	shift uint
+	// hasher is the Hasher used by every shard, kept here so that shards can be selected without
+	// reading a shard's HashMap outside of its lock
+	hasher Hasher
+}
+
+// NewConcurrentHashMap returns an empty ConcurrentHashMap that splits keys across at least the
+// given number of shards (rounded up to a power of two), each of which is a HashMap configured
+// with the given options. If shards is less than 1 a default of 32 is used.
+func NewConcurrentHashMap[K comparable, V any](
+	shards int,
+	opts ...HashTableOption,
+) *ConcurrentHashMap[K, V] {
+	if shards < 1 {
+		shards = defaultConcurrentHashMapShards
+	}
+	shards = nextPowerOfTwo(shards)
+
+	m := &ConcurrentHashMap[K, V]{
+		shards: make([]*concurrentHashMapShard[K, V], shards),
+		shift:  64,
+		hasher: newHashTableConfig(opts).hasher,
+	}
+	for i := range m.shards {
+		m.shards[i] = &concurrentHashMapShard[K, V]{m: NewHashMap[K, V](opts...)}
+	}
+	for n := shards; n > 1; n >>= 1 {
+		m.shift--
+	}
+
+	return m
+}
+
+// NewConcurrentStringHashMap returns an empty ConcurrentHashMap keyed by strings that uses Dbj2Hash
+// to hash keys and the default number of shards.
+func NewConcurrentStringHashMap[V any]() *ConcurrentHashMap[string, V] {
+	return NewConcurrentHashMap[string, V](defaultConcurrentHashMapShards)
+}
+
+// Len returns the number of key/value pairs stored in the map. Concurrent writers may change the
+// length while it is being counted.
+func (m *ConcurrentHashMap[K, V]) Len() int {
+	total := 0
+	for _, shard := range m.shards {
+		shard.mu.RLock()
+		total += shard.m.Len()
+		shard.mu.RUnlock()
+	}
+	return total
+}
+
+// Load returns the value stored under the given key; ok reports whether the key was found.
+func (m *ConcurrentHashMap[K, V]) Load(key K) (value V, ok bool) {
+	shard := m.shard(key)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+
+	value, err := shard.m.Get(key)
+	return value, err == nil
+}
+
+// Store associates the value with the given key, replacing any value previously stored under the
+// key.
+func (m *ConcurrentHashMap[K, V]) Store(key K, value V) {
+	shard := m.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	shard.m.Put(key, value)
+}
+
+// LoadOrStore returns the existing value for the key if present (loaded is true). Otherwise it
+// stores and returns the given value (loaded is false). The check and the store happen atomically.
+func (m *ConcurrentHashMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	shard := m.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if existing, err := shard.m.Get(key); err == nil {
+		return existing, true
+	}
+	shard.m.Put(key, value)
+
+	return value, false
+}
+
+// Delete removes the given key and its value from the map; deleting a key that is not in the map
+// has no effect.
+func (m *ConcurrentHashMap[K, V]) Delete(key K) {
+	shard := m.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	_ = shard.m.Delete(key)
+}
+
+// Range calls f sequentially for each key/value pair in the map, stopping early if f returns
+// false. Each shard is copied while holding its read lock and f is called without holding any lock,
+// so f may safely call other methods on the map. Range does not reflect a consistent snapshot of
+// the whole map; pairs stored or deleted concurrently may or may not be visited.
+func (m *ConcurrentHashMap[K, V]) Range(f func(key K, value V) bool) {
+	type pair struct {
+		key   K
+		value V
+	}
+
+	var pairs []pair
+	for _, shard := range m.shards {
+		pairs = pairs[:0]
+		shard.mu.RLock()
+		for k, v := range shard.m.All() {
+			pairs = append(pairs, pair{k, v})
+		}
+		shard.mu.RUnlock()
+
+		for _, p := range pairs {
+			if !f(p.key, p.value) {
+				return
+			}
+		}
+	}
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// shard returns the shard responsible for the given key. The hash is scrambled with Fibonacci
+// hashing and the shard is chosen from its top bits, which keeps shard selection independent of
+// the low bits each shard's HashMap uses to pick a bucket.
+func (m *ConcurrentHashMap[K, V]) shard(key K) *concurrentHashMapShard[K, V] {
+	if len(m.shards) == 1 {
+		return m.shards[0]
+	}
+	mixed := uint64(hashMapKey(m.hasher, key)) * 11400714819323198485
+	return m.shards[mixed>>m.shift]
+}
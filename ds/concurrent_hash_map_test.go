@@ -0,0 +1,173 @@
+package ds_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConcurrentHashMap(t *testing.T) {
+	t.Run("Should create an empty map", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		assert.Equal(t, 0, m.Len())
+	})
+
+	t.Run("Should work with a single shard", func(t *testing.T) {
+		m := ds.NewConcurrentHashMap[string, int](1, ds.WithHasher(ds.Dbj2Hasher))
+		m.Store("a", 1)
+		v, ok := m.Load("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+	})
+}
+
+func TestConcurrentHashMapLoadStore(t *testing.T) {
+	t.Run("Load on an empty map", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		v, ok := m.Load("missing")
+		assert.False(t, ok)
+		assert.Zero(t, v)
+	})
+
+	t.Run("Store should add and replace values", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		m.Store("a", 1)
+		m.Store("b", 2)
+		m.Store("a", 3)
+		assert.Equal(t, 2, m.Len())
+
+		v, ok := m.Load("a")
+		assert.True(t, ok)
+		assert.Equal(t, 3, v)
+	})
+}
+
+func TestConcurrentHashMapLoadOrStore(t *testing.T) {
+	t.Run("Should store the value when the key is missing", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		actual, loaded := m.LoadOrStore("a", 1)
+		assert.False(t, loaded)
+		assert.Equal(t, 1, actual)
+	})
+
+	t.Run("Should load the existing value when the key is present", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		m.Store("a", 1)
+		actual, loaded := m.LoadOrStore("a", 2)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, actual)
+	})
+}
+
+func TestConcurrentHashMapDelete(t *testing.T) {
+	t.Run("Should remove the key", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		m.Store("a", 1)
+		m.Delete("a")
+		m.Delete("missing")
+		_, ok := m.Load("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, m.Len())
+	})
+}
+
+func TestConcurrentHashMapRange(t *testing.T) {
+	t.Run("Should visit every pair", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		for i := range 100 {
+			m.Store(fmt.Sprintf("key-%d", i), i)
+		}
+
+		visited := make(map[string]int)
+		m.Range(func(k string, v int) bool {
+			visited[k] = v
+			return true
+		})
+		assert.Len(t, visited, 100)
+		assert.Equal(t, 42, visited["key-42"])
+	})
+
+	t.Run("Should stop early and allow the callback to modify the map", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		for i := range 100 {
+			m.Store(fmt.Sprintf("key-%d", i), i)
+		}
+
+		count := 0
+		m.Range(func(k string, v int) bool {
+			m.Delete(k)
+			count++
+			return count < 10
+		})
+		assert.Equal(t, 10, count)
+		assert.Equal(t, 90, m.Len())
+	})
+}
+
+func TestConcurrentHashMapConcurrency(t *testing.T) {
+	t.Run("Should stay consistent when hammered from many goroutines", func(t *testing.T) {
+		const goroutines = 32
+		const keysPerGoroutine = 500
+		m := ds.NewConcurrentHashMap[string, int](8, ds.WithHasher(ds.FNV1aHasher{}))
+
+		var wg sync.WaitGroup
+		for g := range goroutines {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range keysPerGoroutine {
+					key := fmt.Sprintf("g%d-k%d", g, i)
+					m.Store(key, i)
+					if v, ok := m.Load(key); !ok || v != i {
+						t.Errorf("expected to load %d for %s", i, key)
+					}
+					// every goroutine competes to claim the same shared keys
+					m.LoadOrStore(fmt.Sprintf("shared-%d", i), g)
+					if i%2 == 0 {
+						m.Delete(key)
+					}
+				}
+			}()
+		}
+		// read concurrently with the writers
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 10 {
+					m.Range(func(string, int) bool { return true })
+					m.Len()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, goroutines*keysPerGoroutine/2+keysPerGoroutine, m.Len())
+	})
+
+	t.Run("LoadOrStore should only let one goroutine win", func(t *testing.T) {
+		m := ds.NewConcurrentStringHashMap[int]()
+		winners := make(chan int, 64)
+
+		var wg sync.WaitGroup
+		for g := range 64 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, loaded := m.LoadOrStore("contended", g); !loaded {
+					winners <- g
+				}
+			}()
+		}
+		wg.Wait()
+		close(winners)
+
+		assert.Len(t, winners, 1)
+		winner := <-winners
+		v, _ := m.Load("contended")
+		assert.Equal(t, winner, v)
+	})
+}
@@ -0,0 +1,90 @@
+package ds
+
+import (
+	"context"
+	"sync"
+)
+
+// ConcurrentPriorityQueue is a Heap guarded by a lock so that it is safe for concurrent use by
+// multiple goroutines. Besides the usual non-blocking operations, consumers can use PopWait to
+// block until an element is available.
+type ConcurrentPriorityQueue[T any] struct {
+	mu sync.Mutex
+	h  *Heap[T]
+	// ready is closed (and replaced) whenever an element is pushed, waking every goroutine blocked in
+	// PopWait; it is nil while no goroutine is waiting
+	ready chan struct{}
+}
+
+// NewConcurrentPriorityQueue returns a new empty queue ordered by the given less function, which
+// reports whether a should be served before b.
+func NewConcurrentPriorityQueue[T any](less func(a, b T) bool) *ConcurrentPriorityQueue[T] {
+	return &ConcurrentPriorityQueue[T]{
+		h: NewHeap(less),
+	}
+}
+
+// Size returns the number of elements currently in the queue
+func (q *ConcurrentPriorityQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.h.Size()
+}
+
+// Peek returns the element at the front of the queue without removing it; if the queue is empty an
+// error is returned.
+func (q *ConcurrentPriorityQueue[T]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.h.Peek()
+}
+
+// Push adds an element to the queue, waking any goroutines blocked in PopWait
+func (q *ConcurrentPriorityQueue[T]) Push(elem T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.h.Push(elem)
+	if q.ready != nil {
+		close(q.ready)
+		q.ready = nil
+	}
+}
+
+// Pop removes the element at the front of the queue without blocking; if the queue is empty an
+// error is returned.
+func (q *ConcurrentPriorityQueue[T]) Pop() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.h.Pop()
+}
+
+// PopWait removes the element at the front of the queue, blocking until one is available or the
+// context is done, in which case the context's error is returned. When several goroutines are
+// waiting, each pushed element is handed to exactly one of them.
+func (q *ConcurrentPriorityQueue[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if q.h.Size() > 0 {
+			elem, err := q.h.Pop()
+			q.mu.Unlock()
+			return elem, err
+		}
+		if q.ready == nil {
+			q.ready = make(chan struct{})
+		}
+		ready := q.ready
+		q.mu.Unlock()
+
+		select {
+		case <-ready:
+			// another waiter may have taken the element first, so check again
+		case <-ctx.Done():
+			var empty T
+			return empty, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package ds_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentPriorityQueue(t *testing.T) {
+	runPriorityQueueTests(t, func() ds.PriorityQueue[int] { return ds.NewConcurrentPriorityQueue(intLess) }, intLess)
+}
+
+func TestConcurrentPriorityQueuePopWait(t *testing.T) {
+	t.Run("Should return immediately when the queue is not empty", func(t *testing.T) {
+		q := ds.NewConcurrentPriorityQueue(intLess)
+		q.Push(5)
+		q.Push(2)
+
+		elem, err := q.PopWait(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, elem)
+		assert.Equal(t, 1, q.Size())
+	})
+
+	t.Run("Should block until an element is pushed", func(t *testing.T) {
+		q := ds.NewConcurrentPriorityQueue(intLess)
+		done := make(chan int)
+		go func() {
+			elem, _ := q.PopWait(context.Background())
+			done <- elem
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("PopWait returned before an element was pushed")
+		default:
+		}
+		q.Push(7)
+		assert.Equal(t, 7, <-done)
+		assert.Equal(t, 0, q.Size())
+	})
+
+	t.Run("Should return the context's error once it is done", func(t *testing.T) {
+		q := ds.NewConcurrentPriorityQueue(intLess)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			_, err := q.PopWait(ctx)
+			done <- err
+		}()
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+
+		ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		_, err := q.PopWait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Should hand each element to exactly one waiter", func(t *testing.T) {
+		const producers, consumers, perProducer = 4, 8, 500
+		q := ds.NewConcurrentPriorityQueue(intLess)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var mu sync.Mutex
+		var received []int
+		var wg sync.WaitGroup
+		for range consumers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					elem, err := q.PopWait(ctx)
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					received = append(received, elem)
+					if len(received) == producers*perProducer {
+						cancel()
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		for p := range producers {
+			go func() {
+				for i := range perProducer {
+					q.Push(p*perProducer + i)
+				}
+			}()
+		}
+		wg.Wait()
+
+		slices.Sort(received)
+		assert.Len(t, received, producers*perProducer)
+		for i, elem := range received {
+			assert.Equal(t, i, elem)
+		}
+		assert.Equal(t, 0, q.Size())
+	})
+}
@@ -0,0 +1,499 @@
+package ds
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+const (
+	// DefaultPageSize is the size in bytes of each page of a new DiskBPlusTree file.
+	DefaultPageSize = 4096
+	// MinPageSize is the smallest page size a DiskBPlusTree file can be created with.
+	MinPageSize = 128
+	// MaxPageSize is the largest page size a DiskBPlusTree file can be created with. A node page
+	// stores its key count as a uint16, and a leaf of this size holds exactly 65535 key/value pairs.
+	MaxPageSize = 1 << 20
+	// DefaultPageCacheSize is the number of pages a DiskBPlusTree keeps cached in memory.
+	DefaultPageCacheSize = 256
+)
+
+// The first page of a DiskBPlusTree file is the meta page: an 8 byte magic string, the page size as
+// a uint32, 4 reserved bytes, then the root page number, the number of pages in the file and the
+// number of keys in the tree, each as a uint64. Every field is little-endian.
+const (
+	diskMetaPage   uint64 = 0
+	diskMetaLen           = 40
+	diskMagic             = "DSBPTREE"
+	walPathSuffix         = "-wal"
+	diskEntryBytes        = 16
+)
+
+// diskBPlusTreeConfig holds the settings a DiskBPlusTree is opened with.
+type diskBPlusTreeConfig struct {
+	pageSize  int
+	cacheSize int
+}
+
+// DiskBPlusTreeOption configures a DiskBPlusTree when it is opened.
+type DiskBPlusTreeOption func(*diskBPlusTreeConfig)
+
+// WithPageSize sets the size in bytes of each page. It only applies when a new file is created; an
+// existing file is always read with the page size it was created with. Sizes smaller than
+// MinPageSize are raised to MinPageSize; sizes larger than MaxPageSize cause OpenDiskBPlusTree to
+// return an error.
+func WithPageSize(size int) DiskBPlusTreeOption {
+	return func(c *diskBPlusTreeConfig) {
+		c.pageSize = max(size, MinPageSize)
+	}
+}
+
+// WithPageCacheSize sets the number of pages kept cached in memory. Sizes smaller than 1 are raised
+// to 1.
+func WithPageCacheSize(pages int) DiskBPlusTreeOption {
+	return func(c *diskBPlusTreeConfig) {
+		c.cacheSize = max(pages, 1)
+	}
+}
+
+// DiskBPlusTree is a file-backed B+Tree mapping int64 keys to int64 values, for sorted indexes that
+// persist between runs. The file is divided into fixed-size pages, each holding one node, and
+// recently used pages are kept decoded in an LRU page cache. Lookups and scans use the same search
+// logic as BPlusTree, reading nodes through the page cache; the write path is its own, since nodes
+// carry values, are referred to by page number and fill up by bytes rather than by key count.
+//
+// Changes are held in the cache until Sync (or Close) is called. Sync first writes every changed
+// page to a write-ahead log next to the data file and only then updates the data file, so the tree
+// on disk always reflects the last completed Sync even if the process crashes part way through.
+//
+// Unlike BPlusTree, removing keys never merges or redistributes nodes: a page stays allocated (and
+// the tree keeps its height) even once every key in it has been deleted, so a file that is mostly
+// deleted keeps all of its pages and never shrinks. A DiskBPlusTree is not safe for concurrent use.
+type DiskBPlusTree struct {
+	file  *os.File
+	log   *writeAheadLog
+	cache *pageCache
+
+	pageSize  int
+	root      uint64
+	pageCount uint64
+	len       int
+	// metaDirty is set when the root, page count or length have changed since the last Sync
+	metaDirty bool
+	closed    bool
+}
+
+// OpenDiskBPlusTree opens the tree stored in the file at the given path, creating a new, empty tree
+// if the file does not exist. Any changes that were logged but not fully written to the file when a
+// previous process crashed are recovered first.
+func OpenDiskBPlusTree(path string, opts ...DiskBPlusTreeOption) (*DiskBPlusTree, error) {
+	config := diskBPlusTreeConfig{
+		pageSize:  DefaultPageSize,
+		cacheSize: DefaultPageCacheSize,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.pageSize > MaxPageSize {
+		return nil, fmt.Errorf("page size %d is larger than the maximum of %d", config.pageSize, MaxPageSize)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	log, err := openWriteAheadLog(path + walPathSuffix)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	t := &DiskBPlusTree{file: file, log: log}
+	if err := t.open(config); err != nil {
+		t.closeFiles()
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Len returns the number of keys in the tree.
+func (t DiskBPlusTree) Len() int {
+	return t.len
+}
+
+// Get returns the value stored under the given key; if the key is not found an error is returned.
+func (t *DiskBPlusTree) Get(key int64) (int64, error) {
+	if t.closed {
+		return 0, errors.New("tree is closed")
+	}
+	defer t.cache.evict()
+
+	leaf, err := t.findLeaf(key)
+	if err != nil {
+		return 0, err
+	}
+	i, found := slices.BinarySearch(leaf.keys, key)
+	if !found {
+		return 0, errors.New("key not found")
+	}
+
+	return leaf.values[i], nil
+}
+
+// Contains returns true if a value is stored under the given key; else it returns false.
+func (t *DiskBPlusTree) Contains(key int64) (bool, error) {
+	if t.closed {
+		return false, errors.New("tree is closed")
+	}
+	defer t.cache.evict()
+
+	leaf, err := t.findLeaf(key)
+	if err != nil {
+		return false, err
+	}
+	_, found := slices.BinarySearch(leaf.keys, key)
+
+	return found, nil
+}
+
+// Put associates the value with the given key, replacing any value previously stored under the key.
+func (t *DiskBPlusTree) Put(key int64, value int64) error {
+	if t.closed {
+		return errors.New("tree is closed")
+	}
+	defer t.cache.evict()
+
+	separator, sibling, err := t.put(t.root, key, value)
+	if err != nil {
+		return err
+	}
+
+	if sibling != nil {
+		// grow the tree upwards by giving the split root a new parent
+		root := t.allocate(false)
+		root.keys = []int64{separator}
+		root.children = []uint64{t.root, sibling.id}
+		t.root = root.id
+		t.metaDirty = true
+	}
+
+	return nil
+}
+
+// Delete removes the given key and its value from the tree; if the key is not found an error is
+// returned.
+func (t *DiskBPlusTree) Delete(key int64) error {
+	if t.closed {
+		return errors.New("tree is closed")
+	}
+	defer t.cache.evict()
+
+	leaf, err := t.findLeaf(key)
+	if err != nil {
+		return err
+	}
+	i, found := slices.BinarySearch(leaf.keys, key)
+	if !found {
+		return errors.New("key not found")
+	}
+
+	leaf.keys = slices.Delete(leaf.keys, i, i+1)
+	leaf.values = slices.Delete(leaf.values, i, i+1)
+	leaf.dirty = true
+	t.len--
+	t.metaDirty = true
+
+	return nil
+}
+
+// Scan calls visit for each key/value pair with a key between lo and hi (inclusive) in ascending
+// order of key, stopping early if visit returns false. The tree must not be modified during the
+// scan.
+func (t *DiskBPlusTree) Scan(lo, hi int64, visit func(key, value int64) bool) error {
+	if t.closed {
+		return errors.New("tree is closed")
+	}
+	defer t.cache.evict()
+
+	leaf, err := t.findLeaf(lo)
+	if err != nil {
+		return err
+	}
+	i, _ := slices.BinarySearch(leaf.keys, lo)
+
+	return bPlusScan[int64, *diskNode](t, leaf, i, &hi, func(leaf *diskNode, i int) bool {
+		return visit(leaf.keys[i], leaf.values[i])
+	})
+}
+
+// Sync makes every change since the previous Sync durable. The changed pages are written to the
+// write-ahead log and synced before they are written to the data file, and the log is emptied once
+// the data file has been synced.
+func (t *DiskBPlusTree) Sync() error {
+	if t.closed {
+		return errors.New("tree is closed")
+	}
+
+	pages := t.dirtyPages()
+	if len(pages) == 0 {
+		return nil
+	}
+	if err := t.log.commit(pages); err != nil {
+		return fmt.Errorf("writing the write-ahead log: %w", err)
+	}
+	if err := t.writePages(pages); err != nil {
+		return err
+	}
+	if err := t.log.reset(); err != nil {
+		return err
+	}
+
+	for _, node := range t.cache.dirtyNodes() {
+		node.dirty = false
+	}
+	t.metaDirty = false
+	t.cache.evict()
+
+	return nil
+}
+
+// Close syncs any outstanding changes and closes the tree's files. The tree cannot be used after it
+// has been closed.
+func (t *DiskBPlusTree) Close() error {
+	if t.closed {
+		return errors.New("tree is closed")
+	}
+
+	if err := t.Sync(); err != nil {
+		return err
+	}
+
+	return t.closeFiles()
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// open recovers the write-ahead log and then either reads the meta page of an existing file or
+// initialises a new file.
+func (t *DiskBPlusTree) open(config diskBPlusTreeConfig) error {
+	pages, err := t.log.recover()
+	if err != nil {
+		return fmt.Errorf("reading the write-ahead log: %w", err)
+	}
+	if len(pages) > 0 {
+		if err := t.writePages(pages); err != nil {
+			return err
+		}
+	}
+	if err := t.log.reset(); err != nil {
+		return err
+	}
+
+	info, err := t.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() == 0 {
+		// start a new tree with a single, empty leaf as the root
+		t.pageSize = config.pageSize
+		t.cache = newPageCache(t.file, t.pageSize, config.cacheSize)
+		t.pageCount = 1
+		t.root = t.allocate(true).id
+		t.metaDirty = true
+		return t.Sync()
+	}
+
+	meta := make([]byte, diskMetaLen)
+	if _, err := t.file.ReadAt(meta, 0); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("file is not a DiskBPlusTree")
+		}
+		return err
+	}
+	if string(meta[:8]) != diskMagic {
+		return errors.New("file is not a DiskBPlusTree")
+	}
+
+	t.pageSize = int(binary.LittleEndian.Uint32(meta[8:]))
+	if t.pageSize < MinPageSize || t.pageSize > MaxPageSize {
+		return errors.New("file has an invalid page size")
+	}
+	t.root = binary.LittleEndian.Uint64(meta[16:])
+	t.pageCount = binary.LittleEndian.Uint64(meta[24:])
+	t.len = int(binary.LittleEndian.Uint64(meta[32:]))
+	t.cache = newPageCache(t.file, t.pageSize, config.cacheSize)
+
+	return nil
+}
+
+// closeFiles closes the data and log files without syncing.
+func (t *DiskBPlusTree) closeFiles() error {
+	t.closed = true
+	return errors.Join(t.file.Close(), t.log.close())
+}
+
+// leafCapacity returns the maximum number of key/value pairs a leaf page holds.
+func (t DiskBPlusTree) leafCapacity() int {
+	return (t.pageSize - diskNodeHeaderSize) / diskEntryBytes
+}
+
+// internalCapacity returns the maximum number of keys an internal page holds.
+func (t DiskBPlusTree) internalCapacity() int {
+	return (t.pageSize - diskNodeHeaderSize - 8) / diskEntryBytes
+}
+
+// allocate assigns the next page number to a new, empty node and adds it to the cache.
+func (t *DiskBPlusTree) allocate(leaf bool) *diskNode {
+	node := &diskNode{id: t.pageCount, leaf: leaf}
+	t.pageCount++
+	t.metaDirty = true
+	t.cache.add(node)
+
+	return node
+}
+
+// rootNode, child, nextLeaf, keysOf and isLeafNode let the search logic shared with BPlusTree walk
+// the tree's pages through the page cache.
+func (t *DiskBPlusTree) rootNode() (*diskNode, error) {
+	return t.cache.get(t.root)
+}
+
+func (t *DiskBPlusTree) child(node *diskNode, i int) (*diskNode, error) {
+	return t.cache.get(node.children[i])
+}
+
+func (t *DiskBPlusTree) nextLeaf(leaf *diskNode) (*diskNode, bool, error) {
+	if leaf.next == 0 {
+		return nil, false, nil
+	}
+
+	next, err := t.cache.get(leaf.next)
+	if err != nil {
+		return nil, false, err
+	}
+	// only the next leaf is needed from here on
+	t.cache.evict()
+
+	return next, true, nil
+}
+
+func (t *DiskBPlusTree) keysOf(node *diskNode) []int64 {
+	return node.keys
+}
+
+func (t *DiskBPlusTree) isLeafNode(node *diskNode) bool {
+	return node.leaf
+}
+
+// findLeaf returns the leaf whose range of keys covers the given key.
+func (t *DiskBPlusTree) findLeaf(key int64) (*diskNode, error) {
+	return bPlusFindLeaf[int64, *diskNode](t, key)
+}
+
+// put stores the key/value pair in the subtree rooted at the given page. If the node overflows its
+// page it is split in two, and the new right-hand sibling is returned along with the separator key
+// that belongs between the two nodes in their parent.
+func (t *DiskBPlusTree) put(id uint64, key int64, value int64) (int64, *diskNode, error) {
+	node, err := t.cache.get(id)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if node.leaf {
+		i, found := slices.BinarySearch(node.keys, key)
+		node.dirty = true
+		if found {
+			node.values[i] = value
+			return 0, nil, nil
+		}
+		node.keys = slices.Insert(node.keys, i, key)
+		node.values = slices.Insert(node.values, i, value)
+		t.len++
+		t.metaDirty = true
+		if len(node.keys) <= t.leafCapacity() {
+			return 0, nil, nil
+		}
+	} else {
+		i := bPlusChildIndex(node.keys, key)
+		separator, sibling, err := t.put(node.children[i], key, value)
+		if err != nil || sibling == nil {
+			return 0, nil, err
+		}
+		node.keys = slices.Insert(node.keys, i, separator)
+		node.children = slices.Insert(node.children, i+1, sibling.id)
+		node.dirty = true
+		if len(node.keys) <= t.internalCapacity() {
+			return 0, nil, nil
+		}
+	}
+
+	separator, sibling := t.split(node)
+	return separator, sibling, nil
+}
+
+// split divides an overflowing node in two and returns the separator key for its parent along with
+// the new right-hand sibling.
+func (t *DiskBPlusTree) split(node *diskNode) (int64, *diskNode) {
+	mid := len(node.keys) / 2
+	sibling := t.allocate(node.leaf)
+
+	if node.leaf {
+		// the separator is copied up; every key stays in a leaf
+		sibling.keys = slices.Clone(node.keys[mid:])
+		sibling.values = slices.Clone(node.values[mid:])
+		sibling.next = node.next
+		node.keys = node.keys[:mid]
+		node.values = node.values[:mid]
+		node.next = sibling.id
+		return sibling.keys[0], sibling
+	}
+
+	// the separator moves up; it already appears in a leaf below
+	separator := node.keys[mid]
+	sibling.keys = slices.Clone(node.keys[mid+1:])
+	sibling.children = slices.Clone(node.children[mid+1:])
+	node.keys = node.keys[:mid]
+	node.children = node.children[:mid+1]
+
+	return separator, sibling
+}
+
+// dirtyPages encodes the meta page (if it has changed) and every dirty node page.
+func (t *DiskBPlusTree) dirtyPages() []walPage {
+	var pages []walPage
+
+	if t.metaDirty {
+		data := make([]byte, t.pageSize)
+		copy(data, diskMagic)
+		binary.LittleEndian.PutUint32(data[8:], uint32(t.pageSize))
+		binary.LittleEndian.PutUint64(data[16:], t.root)
+		binary.LittleEndian.PutUint64(data[24:], t.pageCount)
+		binary.LittleEndian.PutUint64(data[32:], uint64(t.len))
+		pages = append(pages, walPage{id: diskMetaPage, data: data})
+	}
+
+	for _, node := range t.cache.dirtyNodes() {
+		data := make([]byte, t.pageSize)
+		node.encode(data)
+		pages = append(pages, walPage{id: node.id, data: data})
+	}
+
+	return pages
+}
+
+// writePages writes the page images into the data file and syncs it.
+func (t *DiskBPlusTree) writePages(pages []walPage) error {
+	for _, page := range pages {
+		if _, err := t.file.WriteAt(page.data, int64(page.id)*int64(len(page.data))); err != nil {
+			return fmt.Errorf("writing page %d: %w", page.id, err)
+		}
+	}
+
+	return t.file.Sync()
+}
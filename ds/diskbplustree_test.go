@@ -0,0 +1,296 @@
+package ds_test
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// openTestDiskBPlusTree opens a tree with small pages in the test's temporary directory, so that
+// a few hundred keys are enough to build a multi-level tree.
+func openTestDiskBPlusTree(t *testing.T, path string, opts ...ds.DiskBPlusTreeOption) *ds.DiskBPlusTree {
+	tree, err := ds.OpenDiskBPlusTree(path, append([]ds.DiskBPlusTreeOption{ds.WithPageSize(ds.MinPageSize)}, opts...)...)
+	assert.Nil(t, err)
+	return tree
+}
+
+// scanAll returns every key/value pair in the tree.
+func scanAll(t *testing.T, tree *ds.DiskBPlusTree) map[int64]int64 {
+	pairs := map[int64]int64{}
+	var keys []int64
+	err := tree.Scan(-1<<63, 1<<63-1, func(key, value int64) bool {
+		pairs[key] = value
+		keys = append(keys, key)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.True(t, slices.IsSorted(keys))
+	return pairs
+}
+
+func TestOpenDiskBPlusTree(t *testing.T) {
+	t.Run("Should create a new, empty tree", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		tree := openTestDiskBPlusTree(t, path)
+		assert.Equal(t, 0, tree.Len())
+		assert.Nil(t, tree.Close())
+
+		tree = openTestDiskBPlusTree(t, path)
+		assert.Equal(t, 0, tree.Len())
+		assert.Empty(t, scanAll(t, tree))
+		assert.Nil(t, tree.Close())
+	})
+
+	t.Run("Should return an error for a file that is not a tree", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		assert.Nil(t, os.WriteFile(path, []byte("definitely not a B+Tree file, but long enough"), 0o644))
+		_, err := ds.OpenDiskBPlusTree(path)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Should keep the page size the file was created with", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		tree := openTestDiskBPlusTree(t, path)
+		for i := range int64(500) {
+			assert.Nil(t, tree.Put(i, i*10))
+		}
+		assert.Nil(t, tree.Close())
+
+		tree, err := ds.OpenDiskBPlusTree(path, ds.WithPageSize(8192))
+		assert.Nil(t, err)
+		assert.Equal(t, 500, tree.Len())
+		v, err := tree.Get(499)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4990), v)
+		assert.Nil(t, tree.Close())
+	})
+
+	t.Run("Should reject a page size larger than MaxPageSize", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		_, err := ds.OpenDiskBPlusTree(path, ds.WithPageSize(ds.MaxPageSize+1))
+		assert.NotNil(t, err)
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("Should reject a file whose page size is too large", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		meta := make([]byte, 64)
+		copy(meta, "DSBPTREE")
+		binary.LittleEndian.PutUint32(meta[8:], 2*ds.MaxPageSize)
+		assert.Nil(t, os.WriteFile(path, meta, 0o644))
+
+		_, err := ds.OpenDiskBPlusTree(path)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Should keep every key of a full leaf at the maximum page size", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		tree, err := ds.OpenDiskBPlusTree(path, ds.WithPageSize(ds.MaxPageSize))
+		assert.Nil(t, err)
+		// the root leaf fills up without splitting
+		const full = (ds.MaxPageSize - 16) / 16
+		for i := range int64(full) {
+			assert.Nil(t, tree.Put(i, i))
+		}
+		assert.Nil(t, tree.Close())
+
+		tree, err = ds.OpenDiskBPlusTree(path)
+		assert.Nil(t, err)
+		assert.Equal(t, full, tree.Len())
+		assert.Len(t, scanAll(t, tree), full)
+		assert.Nil(t, tree.Close())
+	})
+}
+
+func TestDiskBPlusTreeOperations(t *testing.T) {
+	t.Run("Should match a map over random operations and survive reopening", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		tree := openTestDiskBPlusTree(t, path, ds.WithPageCacheSize(8))
+		r := rand.New(rand.NewSource(17))
+		oracle := map[int64]int64{}
+
+		for round := range 5 {
+			for range 2000 {
+				key := int64(r.Intn(3000)) - 1000
+				switch r.Intn(3) {
+				case 0, 1:
+					value := r.Int63()
+					assert.Nil(t, tree.Put(key, value))
+					oracle[key] = value
+				case 2:
+					_, ok := oracle[key]
+					assert.Equal(t, ok, tree.Delete(key) == nil)
+					delete(oracle, key)
+				}
+			}
+			assert.Equal(t, len(oracle), tree.Len())
+
+			if round%2 == 0 {
+				assert.Nil(t, tree.Sync())
+			} else {
+				assert.Nil(t, tree.Close())
+				tree = openTestDiskBPlusTree(t, path, ds.WithPageCacheSize(8))
+			}
+
+			assert.Equal(t, oracle, scanAll(t, tree))
+			for key := int64(-1000); key < 2000; key += 13 {
+				want, ok := oracle[key]
+				v, err := tree.Get(key)
+				assert.Equal(t, ok, err == nil)
+				assert.Equal(t, want, v)
+				found, err := tree.Contains(key)
+				assert.Nil(t, err)
+				assert.Equal(t, ok, found)
+			}
+		}
+		assert.Nil(t, tree.Close())
+	})
+
+	t.Run("Should replace the value of an existing key", func(t *testing.T) {
+		tree := openTestDiskBPlusTree(t, filepath.Join(t.TempDir(), "index.db"))
+		assert.Nil(t, tree.Put(1, 10))
+		assert.Nil(t, tree.Put(1, 20))
+		assert.Equal(t, 1, tree.Len())
+		v, err := tree.Get(1)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(20), v)
+		assert.Nil(t, tree.Close())
+	})
+
+	t.Run("Should scan a bounded range and stop when visit returns false", func(t *testing.T) {
+		tree := openTestDiskBPlusTree(t, filepath.Join(t.TempDir(), "index.db"))
+		for i := range int64(1000) {
+			tree.Put(i, -i)
+		}
+
+		var keys []int64
+		err := tree.Scan(100, 199, func(key, value int64) bool {
+			assert.Equal(t, -key, value)
+			keys = append(keys, key)
+			return true
+		})
+		assert.Nil(t, err)
+		assert.Len(t, keys, 100)
+		assert.Equal(t, int64(100), keys[0])
+		assert.Equal(t, int64(199), keys[99])
+
+		count := 0
+		err = tree.Scan(0, 999, func(key, value int64) bool {
+			count++
+			return count < 5
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 5, count)
+		assert.Nil(t, tree.Close())
+	})
+
+	t.Run("Should return errors once closed", func(t *testing.T) {
+		tree := openTestDiskBPlusTree(t, filepath.Join(t.TempDir(), "index.db"))
+		assert.Nil(t, tree.Close())
+		assert.NotNil(t, tree.Put(1, 1))
+		_, err := tree.Get(1)
+		assert.NotNil(t, err)
+		assert.NotNil(t, tree.Sync())
+		assert.NotNil(t, tree.Close())
+	})
+}
+
+func TestDiskBPlusTreePageCache(t *testing.T) {
+	t.Run("Should keep dirty pages until Sync and then evict down to capacity", func(t *testing.T) {
+		tree := openTestDiskBPlusTree(t, filepath.Join(t.TempDir(), "index.db"), ds.WithPageCacheSize(4))
+		for i := range int64(1000) {
+			assert.Nil(t, tree.Put(i, i))
+		}
+		assert.Greater(t, ds.DiskBPlusTreeCachedPages(tree), 4)
+
+		assert.Nil(t, tree.Sync())
+		assert.LessOrEqual(t, ds.DiskBPlusTreeCachedPages(tree), 4)
+
+		// pages read back in by a scan are evicted again instead of growing the cache
+		assert.Len(t, scanAll(t, tree), 1000)
+		assert.LessOrEqual(t, ds.DiskBPlusTreeCachedPages(tree), 4)
+		assert.Nil(t, tree.Close())
+	})
+}
+
+func TestDiskBPlusTreeRecovery(t *testing.T) {
+	// setup creates a synced tree holding keys 0-299 and then makes further changes that have not
+	// been synced yet.
+	setup := func(t *testing.T) (string, *ds.DiskBPlusTree) {
+		path := filepath.Join(t.TempDir(), "index.db")
+		tree := openTestDiskBPlusTree(t, path)
+		for i := range int64(300) {
+			assert.Nil(t, tree.Put(i, i))
+		}
+		assert.Nil(t, tree.Sync())
+
+		for i := range int64(300) {
+			assert.Nil(t, tree.Put(i+300, i))
+		}
+		assert.Nil(t, tree.Delete(0))
+		return path, tree
+	}
+
+	t.Run("Should lose only the changes made since the last Sync after a crash", func(t *testing.T) {
+		path, tree := setup(t)
+		assert.Nil(t, ds.CrashDiskBPlusTree(tree))
+
+		tree = openTestDiskBPlusTree(t, path)
+		assert.Equal(t, 300, tree.Len())
+		pairs := scanAll(t, tree)
+		assert.Len(t, pairs, 300)
+		assert.Contains(t, pairs, int64(0))
+		assert.NotContains(t, pairs, int64(300))
+		assert.Nil(t, tree.Close())
+	})
+
+	t.Run("Should replay changes that were logged before a crash", func(t *testing.T) {
+		path, tree := setup(t)
+		assert.Nil(t, ds.CrashDiskBPlusTreeAfterLog(tree))
+
+		tree = openTestDiskBPlusTree(t, path)
+		assert.Equal(t, 599, tree.Len())
+		pairs := scanAll(t, tree)
+		assert.Len(t, pairs, 599)
+		assert.NotContains(t, pairs, int64(0))
+		assert.Contains(t, pairs, int64(599))
+		assert.Nil(t, tree.Close())
+
+		info, err := os.Stat(path + "-wal")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), info.Size())
+	})
+
+	t.Run("Should ignore a transaction whose commit record was torn", func(t *testing.T) {
+		path, tree := setup(t)
+		assert.Nil(t, ds.CrashDiskBPlusTreeAfterLog(tree))
+		info, err := os.Stat(path + "-wal")
+		assert.Nil(t, err)
+		assert.Nil(t, os.Truncate(path+"-wal", info.Size()-1))
+
+		tree = openTestDiskBPlusTree(t, path)
+		assert.Equal(t, 300, tree.Len())
+		assert.Len(t, scanAll(t, tree), 300)
+		assert.Nil(t, tree.Close())
+	})
+
+	t.Run("Should ignore a transaction containing a corrupt page", func(t *testing.T) {
+		path, tree := setup(t)
+		assert.Nil(t, ds.CrashDiskBPlusTreeAfterLog(tree))
+		log, err := os.ReadFile(path + "-wal")
+		assert.Nil(t, err)
+		log[len(log)/2] ^= 0xff
+		assert.Nil(t, os.WriteFile(path+"-wal", log, 0o644))
+
+		tree = openTestDiskBPlusTree(t, path)
+		assert.Equal(t, 300, tree.Len())
+		assert.Len(t, scanAll(t, tree), 300)
+		assert.Nil(t, tree.Close())
+	})
+}
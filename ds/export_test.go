@@ -0,0 +1,53 @@
+package ds
+
+import "golang.org/x/exp/constraints"
+
+// The functions below expose internal counters to the external ds_test package for use in tests and
+// benchmarks.
+
+// AVLTreeRotations returns the number of rotations performed by the tree.
+func AVLTreeRotations[T constraints.Ordered](t *AVLTree[T]) int {
+	return t.rotations
+}
+
+// RedBlackTreeRotations returns the number of rotations performed by the tree.
+func RedBlackTreeRotations[T constraints.Ordered](t *RedBlackTree[T]) int {
+	return t.rotations
+}
+
+// PersistentAVLTreeRoot returns the root node of a version of the tree.
+func PersistentAVLTreeRoot[T constraints.Ordered](t *PersistentAVLTree[T]) *AVLTreeNode[T] {
+	return t.root
+}
+
+// DiskBPlusTreeCachedPages returns the number of pages held in the tree's page cache.
+func DiskBPlusTreeCachedPages(t *DiskBPlusTree) int {
+	return t.cache.Len()
+}
+
+// CrashDiskBPlusTree closes the tree's files without syncing, as if the process had crashed.
+func CrashDiskBPlusTree(t *DiskBPlusTree) error {
+	return t.closeFiles()
+}
+
+// CrashDiskBPlusTreeAfterLog commits the outstanding changes to the write-ahead log and then closes
+// the tree's files without writing the changes to the data file, as if the process had crashed part
+// way through Sync.
+func CrashDiskBPlusTreeAfterLog(t *DiskBPlusTree) error {
+	if err := t.log.commit(t.dirtyPages()); err != nil {
+		return err
+	}
+	return t.closeFiles()
+}
+
+// HeapCapacity returns the capacity of the heap's underlying slice.
+func HeapCapacity[T any](h *Heap[T]) int {
+	return cap(h.s) - 1
+}
+
+// TimerWheelOverflowLen returns the number of timers waiting in the wheel's overflow heap.
+func TimerWheelOverflowLen[T any](w *TimerWheel[T]) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.overflow.Size()
+}
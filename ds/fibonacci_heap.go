@@ -0,0 +1,264 @@
+package ds
+
+import "errors"
+
+// FibonacciHeapNode is an element of a FibonacciHeap. The node returned when an element is inserted
+// is used to decrease the element's key later on.
+type FibonacciHeapNode[T any] struct {
+	elem   T
+	parent *FibonacciHeapNode[T]
+	// child is any one of the node's children, which form a circular doubly linked list
+	child *FibonacciHeapNode[T]
+	// left and right are the node's siblings in a circular doubly linked list
+	left   *FibonacciHeapNode[T]
+	right  *FibonacciHeapNode[T]
+	degree int
+	// mark is set when the node has lost a child since it last became the child of another node
+	mark bool
+	// removed is set once the node has been popped from the heap
+	removed bool
+}
+
+// Value returns the element stored in the node.
+func (n FibonacciHeapNode[T]) Value() T {
+	return n.elem
+}
+
+// FibonacciHeap is a collection of heap-ordered trees ordered by a less function. Inserting and
+// melding simply add trees to the root list in O(1) time, and decreasing a key cuts the node out of
+// its tree in O(1) amortized time; the trees are only consolidated when Pop is called, which takes
+// O(log n) amortized time.
+type FibonacciHeap[T any] struct {
+	// top is the root holding the element at the top of the heap; the roots form a circular doubly
+	// linked list
+	top  *FibonacciHeapNode[T]
+	size int
+	less func(a, b T) bool
+}
+
+// NewFibonacciHeap returns a new empty heap ordered by the given less function, which reports
+// whether a belongs closer to the top of the heap than b.
+func NewFibonacciHeap[T any](less func(a, b T) bool) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{
+		top:  nil,
+		size: 0,
+		less: less,
+	}
+}
+
+// Size returns the number of elements currently in the heap
+func (h FibonacciHeap[T]) Size() int {
+	return h.size
+}
+
+// Peek returns the element at the top of the heap without removing it
+func (h FibonacciHeap[T]) Peek() (T, error) {
+	if h.top == nil {
+		var empty T
+		return empty, errors.New("cannot peek an empty heap")
+	}
+	return h.top.elem, nil
+}
+
+// Push adds an element to the heap in O(1) time
+func (h *FibonacciHeap[T]) Push(elem T) {
+	h.Insert(elem)
+}
+
+// Insert adds an element to the heap in O(1) time and returns the node holding it, which can be
+// passed to DecreaseKey.
+func (h *FibonacciHeap[T]) Insert(elem T) *FibonacciHeapNode[T] {
+	node := &FibonacciHeapNode[T]{elem: elem}
+	node.left, node.right = node, node
+	h.addRoot(node)
+	h.size++
+
+	return node
+}
+
+// Pop removes the element at the top of the heap in O(log n) amortized time. The children of the
+// removed root join the root list, and roots of equal degree are then linked together until every
+// root has a distinct degree.
+func (h *FibonacciHeap[T]) Pop() (T, error) {
+	if h.top == nil {
+		var empty T
+		return empty, errors.New("cannot pop an empty heap")
+	}
+
+	top := h.top
+	// move every child of the top node into the root list
+	if top.child != nil {
+		child := top.child
+		for {
+			child.parent = nil
+			child = child.right
+			if child == top.child {
+				break
+			}
+		}
+		spliceFibonacciLists(top, top.child)
+		top.child = nil
+	}
+
+	if top.right == top {
+		h.top = nil
+	} else {
+		h.top = top.right
+		unlinkFibonacciNode(top)
+		h.consolidate()
+	}
+
+	h.size--
+	top.removed = true
+
+	return top.elem, nil
+}
+
+// DecreaseKey replaces the node's element with one that is no further from the top of the heap in
+// O(1) amortized time. If the node now belongs above its parent it is cut out of its tree, along
+// with any marked ancestors. If the new element belongs further from the top of the heap than the
+// current one, or the node is no longer in the heap, an error is returned. The node must have been
+// inserted into this heap or into a heap that has since been melded into it.
+func (h *FibonacciHeap[T]) DecreaseKey(node *FibonacciHeapNode[T], elem T) error {
+	if node.removed {
+		return errors.New("node is not in the heap")
+	}
+	if h.less(node.elem, elem) {
+		return errors.New("new element belongs further from the top of the heap")
+	}
+
+	node.elem = elem
+	if parent := node.parent; parent != nil && h.less(node.elem, parent.elem) {
+		h.cut(node)
+		// cascade up the tree, cutting every ancestor that has already lost a child
+		for parent.parent != nil {
+			if !parent.mark {
+				parent.mark = true
+				break
+			}
+			grandparent := parent.parent
+			h.cut(parent)
+			parent = grandparent
+		}
+	}
+	if h.less(node.elem, h.top.elem) {
+		h.top = node
+	}
+
+	return nil
+}
+
+// Meld moves every element of the other heap into this heap in O(1) time, leaving the other heap
+// empty. Both heaps must be ordered by the same less function.
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other == h || other.top == nil {
+		return
+	}
+
+	h.addRoot(other.top)
+	h.size += other.size
+	other.top = nil
+	other.size = 0
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// addRoot splices the circular list containing the given node into the root list, updating the top
+// of the heap if needed.
+func (h *FibonacciHeap[T]) addRoot(node *FibonacciHeapNode[T]) {
+	if h.top == nil {
+		h.top = node
+		return
+	}
+
+	spliceFibonacciLists(h.top, node)
+	if h.less(node.elem, h.top.elem) {
+		h.top = node
+	}
+}
+
+// consolidate links roots of equal degree together until no two roots share a degree, then finds
+// the new top of the heap.
+func (h *FibonacciHeap[T]) consolidate() {
+	// collect the roots first, as linking them rewrites the root list
+	var roots []*FibonacciHeapNode[T]
+	for node := h.top; ; {
+		roots = append(roots, node)
+		node = node.right
+		if node == h.top {
+			break
+		}
+	}
+
+	var byDegree []*FibonacciHeapNode[T]
+	for _, node := range roots {
+		node.left, node.right = node, node
+		for node.degree < len(byDegree) && byDegree[node.degree] != nil {
+			other := byDegree[node.degree]
+			byDegree[node.degree] = nil
+			if h.less(other.elem, node.elem) {
+				node, other = other, node
+			}
+			h.link(other, node)
+		}
+		for node.degree >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[node.degree] = node
+	}
+
+	h.top = nil
+	for _, node := range byDegree {
+		if node != nil {
+			h.addRoot(node)
+		}
+	}
+}
+
+// link makes the child root a child of the parent root.
+func (h *FibonacciHeap[T]) link(child, parent *FibonacciHeapNode[T]) {
+	child.left, child.right = child, child
+	child.parent = parent
+	child.mark = false
+	if parent.child == nil {
+		parent.child = child
+	} else {
+		spliceFibonacciLists(parent.child, child)
+	}
+	parent.degree++
+}
+
+// cut moves the node from its parent's children into the root list.
+func (h *FibonacciHeap[T]) cut(node *FibonacciHeapNode[T]) {
+	parent := node.parent
+	if node.right == node {
+		parent.child = nil
+	} else {
+		if parent.child == node {
+			parent.child = node.right
+		}
+		unlinkFibonacciNode(node)
+	}
+	parent.degree--
+
+	node.parent = nil
+	node.mark = false
+	node.left, node.right = node, node
+	spliceFibonacciLists(h.top, node)
+}
+
+// spliceFibonacciLists joins two circular lists into one.
+func spliceFibonacciLists[T any](a, b *FibonacciHeapNode[T]) {
+	aRight, bLeft := a.right, b.left
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
+
+// unlinkFibonacciNode removes the node from its circular list, leaving its neighbours linked.
+func unlinkFibonacciNode[T any](node *FibonacciHeapNode[T]) {
+	node.left.right = node.right
+	node.right.left = node.left
+}
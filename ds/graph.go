@@ -0,0 +1,329 @@
+package ds
+
+import (
+	"errors"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Number is the set of types that can be used as edge weights.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Edge is a weighted, labelled edge in a Graph. In an undirected graph From and To are
+// interchangeable.
+type Edge[V comparable, W Number] struct {
+	From   V
+	To     V
+	Weight W
+	Label  string
+}
+
+// edgeAttrs holds the attributes stored against an edge; undirected edges share a single set of
+// attributes between both directions.
+type edgeAttrs[W Number] struct {
+	weight W
+	label  string
+}
+
+// Graph is a weighted graph stored as adjacency maps, which may be either directed or undirected.
+// Vertices are identified by any comparable value and may carry an optional label; every edge
+// carries a weight and an optional label. At most one edge may connect an ordered pair of vertices
+// (an unordered pair in an undirected graph); an edge from a vertex to itself is allowed and counts
+// once towards the vertex's degree in either kind of graph.
+type Graph[V comparable, W Number] struct {
+	directed bool
+	// out holds the edges leaving each vertex, keyed by the vertex at the other end
+	out map[V]map[V]*edgeAttrs[W]
+	// in holds the edges entering each vertex of a directed graph; it is nil for undirected graphs,
+	// where out holds every edge in both directions
+	in map[V]map[V]*edgeAttrs[W]
+	// labels holds the label of each vertex that has one
+	labels map[V]string
+	edges  int
+}
+
+// NewDirectedGraph returns an empty directed graph.
+func NewDirectedGraph[V comparable, W Number]() *Graph[V, W] {
+	return &Graph[V, W]{
+		directed: true,
+		out:      make(map[V]map[V]*edgeAttrs[W]),
+		in:       make(map[V]map[V]*edgeAttrs[W]),
+		labels:   make(map[V]string),
+	}
+}
+
+// NewUndirectedGraph returns an empty undirected graph.
+func NewUndirectedGraph[V comparable, W Number]() *Graph[V, W] {
+	return &Graph[V, W]{
+		directed: false,
+		out:      make(map[V]map[V]*edgeAttrs[W]),
+		labels:   make(map[V]string),
+	}
+}
+
+// Directed returns true if the graph is directed; else it returns false.
+func (g Graph[V, W]) Directed() bool {
+	return g.directed
+}
+
+// NumVertices returns the number of vertices in the graph.
+func (g Graph[V, W]) NumVertices() int {
+	return len(g.out)
+}
+
+// NumEdges returns the number of edges in the graph; each undirected edge is counted once.
+func (g Graph[V, W]) NumEdges() int {
+	return g.edges
+}
+
+// AddVertex adds a vertex with no edges to the graph; if the vertex is already in the graph an
+// error is returned.
+func (g *Graph[V, W]) AddVertex(v V) error {
+	if g.HasVertex(v) {
+		return errors.New("vertex already exists in graph")
+	}
+	g.addVertex(v)
+	return nil
+}
+
+// HasVertex returns true if the vertex is in the graph; else it returns false.
+func (g Graph[V, W]) HasVertex(v V) bool {
+	_, ok := g.out[v]
+	return ok
+}
+
+// RemoveVertex removes the vertex, its label and every edge incident to it from the graph; if the
+// vertex is not in the graph an error is returned.
+func (g *Graph[V, W]) RemoveVertex(v V) error {
+	if !g.HasVertex(v) {
+		return errors.New("vertex not found in graph")
+	}
+
+	for u := range g.out[v] {
+		g.removeEdge(v, u)
+	}
+	if g.directed {
+		for u := range g.in[v] {
+			g.removeEdge(u, v)
+		}
+		delete(g.in, v)
+	}
+	delete(g.out, v)
+	delete(g.labels, v)
+
+	return nil
+}
+
+// VertexLabel returns the label of the vertex, which is empty if no label has been set; if the
+// vertex is not in the graph an error is returned.
+func (g Graph[V, W]) VertexLabel(v V) (string, error) {
+	if !g.HasVertex(v) {
+		return "", errors.New("vertex not found in graph")
+	}
+
+	return g.labels[v], nil
+}
+
+// SetVertexLabel replaces the label of the vertex; if the vertex is not in the graph an error is
+// returned.
+func (g *Graph[V, W]) SetVertexLabel(v V, label string) error {
+	if !g.HasVertex(v) {
+		return errors.New("vertex not found in graph")
+	}
+
+	if label == "" {
+		delete(g.labels, v)
+	} else {
+		g.labels[v] = label
+	}
+	return nil
+}
+
+// Vertices returns an iterator over every vertex in the graph, in no particular order. The graph
+// must not be modified during iteration.
+func (g Graph[V, W]) Vertices() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range g.out {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// AddEdge adds an unlabelled edge with the given weight from one vertex to another, adding either
+// vertex to the graph if it is not already there. If the graph already has an edge between the
+// vertices an error is returned.
+func (g *Graph[V, W]) AddEdge(from, to V, weight W) error {
+	return g.AddLabeledEdge(from, to, weight, "")
+}
+
+// AddLabeledEdge adds an edge with the given weight and label from one vertex to another, adding
+// either vertex to the graph if it is not already there. If the graph already has an edge between
+// the vertices an error is returned.
+func (g *Graph[V, W]) AddLabeledEdge(from, to V, weight W, label string) error {
+	if g.HasEdge(from, to) {
+		return errors.New("edge already exists in graph")
+	}
+
+	if !g.HasVertex(from) {
+		g.addVertex(from)
+	}
+	if !g.HasVertex(to) {
+		g.addVertex(to)
+	}
+
+	attrs := &edgeAttrs[W]{weight: weight, label: label}
+	g.out[from][to] = attrs
+	if g.directed {
+		g.in[to][from] = attrs
+	} else {
+		g.out[to][from] = attrs
+	}
+	g.edges++
+
+	return nil
+}
+
+// HasEdge returns true if the graph has an edge from one vertex to the other; else it returns
+// false.
+func (g Graph[V, W]) HasEdge(from, to V) bool {
+	_, ok := g.out[from][to]
+	return ok
+}
+
+// Edge returns the edge from one vertex to the other; if there is no such edge an error is
+// returned.
+func (g Graph[V, W]) Edge(from, to V) (Edge[V, W], error) {
+	attrs, ok := g.out[from][to]
+	if !ok {
+		return Edge[V, W]{}, errors.New("edge not found in graph")
+	}
+
+	return Edge[V, W]{From: from, To: to, Weight: attrs.weight, Label: attrs.label}, nil
+}
+
+// SetWeight replaces the weight of the edge from one vertex to the other; if there is no such edge
+// an error is returned.
+func (g *Graph[V, W]) SetWeight(from, to V, weight W) error {
+	attrs, ok := g.out[from][to]
+	if !ok {
+		return errors.New("edge not found in graph")
+	}
+
+	attrs.weight = weight
+	return nil
+}
+
+// SetLabel replaces the label of the edge from one vertex to the other; if there is no such edge an
+// error is returned.
+func (g *Graph[V, W]) SetLabel(from, to V, label string) error {
+	attrs, ok := g.out[from][to]
+	if !ok {
+		return errors.New("edge not found in graph")
+	}
+
+	attrs.label = label
+	return nil
+}
+
+// RemoveEdge removes the edge from one vertex to the other, leaving both vertices in the graph; if
+// there is no such edge an error is returned.
+func (g *Graph[V, W]) RemoveEdge(from, to V) error {
+	if !g.HasEdge(from, to) {
+		return errors.New("edge not found in graph")
+	}
+
+	g.removeEdge(from, to)
+	return nil
+}
+
+// Edges returns an iterator over every edge in the graph, in no particular order; each undirected
+// edge is yielded once. The graph must not be modified during iteration.
+func (g Graph[V, W]) Edges() iter.Seq[Edge[V, W]] {
+	return func(yield func(Edge[V, W]) bool) {
+		// an undirected edge is stored under both of its vertices, so skip the second copy
+		seen := make(map[*edgeAttrs[W]]struct{})
+		for from, edges := range g.out {
+			for to, attrs := range edges {
+				if !g.directed {
+					if _, ok := seen[attrs]; ok {
+						continue
+					}
+					seen[attrs] = struct{}{}
+				}
+				if !yield(Edge[V, W]{From: from, To: to, Weight: attrs.weight, Label: attrs.label}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over the vertices that the given vertex has an edge to, along with
+// the weight of each edge, in no particular order. A vertex that is not in the graph has no
+// neighbors. The graph must not be modified during iteration.
+func (g Graph[V, W]) Neighbors(v V) iter.Seq2[V, W] {
+	return func(yield func(V, W) bool) {
+		for u, attrs := range g.out[v] {
+			if !yield(u, attrs.weight) {
+				return
+			}
+		}
+	}
+}
+
+// OutDegree returns the number of edges leaving the vertex, or 0 if the vertex is not in the graph.
+// In an undirected graph this is the same as the vertex's degree.
+func (g Graph[V, W]) OutDegree(v V) int {
+	return len(g.out[v])
+}
+
+// InDegree returns the number of edges entering the vertex, or 0 if the vertex is not in the graph.
+// In an undirected graph this is the same as the vertex's degree.
+func (g Graph[V, W]) InDegree(v V) int {
+	if !g.directed {
+		return len(g.out[v])
+	}
+	return len(g.in[v])
+}
+
+// Degree returns the number of edges incident to the vertex, or 0 if the vertex is not in the
+// graph. In a directed graph this is the sum of the vertex's in-degree and out-degree, less one for
+// a self-loop, which is counted by both but is a single edge.
+func (g Graph[V, W]) Degree(v V) int {
+	if !g.directed {
+		return len(g.out[v])
+	}
+
+	degree := len(g.out[v]) + len(g.in[v])
+	if _, ok := g.out[v][v]; ok {
+		degree--
+	}
+	return degree
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// addVertex adds a vertex that is not yet in the graph.
+func (g *Graph[V, W]) addVertex(v V) {
+	g.out[v] = make(map[V]*edgeAttrs[W])
+	if g.directed {
+		g.in[v] = make(map[V]*edgeAttrs[W])
+	}
+}
+
+// removeEdge removes an edge that is known to be in the graph.
+func (g *Graph[V, W]) removeEdge(from, to V) {
+	delete(g.out[from], to)
+	if g.directed {
+		delete(g.in[to], from)
+	} else {
+		delete(g.out[to], from)
+	}
+	g.edges--
+}
@@ -0,0 +1,339 @@
+package ds_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGraph(t *testing.T) {
+	t.Run("Should create an empty directed graph", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		assert.True(t, g.Directed())
+		assert.Equal(t, 0, g.NumVertices())
+		assert.Equal(t, 0, g.NumEdges())
+	})
+
+	t.Run("Should create an empty undirected graph", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[int, float64]()
+		assert.False(t, g.Directed())
+		assert.Equal(t, 0, g.NumVertices())
+		assert.Equal(t, 0, g.NumEdges())
+	})
+}
+
+func TestGraphAddVertex(t *testing.T) {
+	t.Run("Should add a vertex with no edges", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		assert.Nil(t, g.AddVertex("A"))
+		assert.True(t, g.HasVertex("A"))
+		assert.False(t, g.HasVertex("B"))
+		assert.Equal(t, 1, g.NumVertices())
+		assert.Equal(t, 0, g.Degree("A"))
+	})
+
+	t.Run("Should reject a duplicate vertex", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		assert.NotNil(t, g.AddVertex("A"))
+		assert.Equal(t, 2, g.NumVertices())
+		assert.Equal(t, 1, g.Degree("A"), "Should keep the vertex's edges")
+	})
+}
+
+func TestGraphAddEdge(t *testing.T) {
+	t.Run("Should add a directed edge and its vertices", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		assert.Nil(t, g.AddEdge("A", "B", 5))
+		assert.Equal(t, 2, g.NumVertices())
+		assert.Equal(t, 1, g.NumEdges())
+		assert.True(t, g.HasEdge("A", "B"))
+		assert.False(t, g.HasEdge("B", "A"))
+
+		edge, err := g.Edge("A", "B")
+		assert.Nil(t, err)
+		assert.Equal(t, ds.Edge[string, int]{From: "A", To: "B", Weight: 5}, edge)
+		_, err = g.Edge("B", "A")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Should allow an edge in each direction of a directed graph", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		assert.Nil(t, g.AddEdge("A", "B", 5))
+		assert.Nil(t, g.AddEdge("B", "A", 7))
+		assert.NotNil(t, g.AddEdge("A", "B", 9))
+		assert.Equal(t, 2, g.NumEdges())
+
+		edge, _ := g.Edge("A", "B")
+		assert.Equal(t, 5, edge.Weight)
+	})
+
+	t.Run("Should add an undirected edge in both directions", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, float64]()
+		assert.Nil(t, g.AddEdge("A", "B", 2.5))
+		assert.NotNil(t, g.AddEdge("B", "A", 1))
+		assert.Equal(t, 1, g.NumEdges())
+		assert.True(t, g.HasEdge("B", "A"))
+
+		edge, err := g.Edge("B", "A")
+		assert.Nil(t, err)
+		assert.Equal(t, 2.5, edge.Weight)
+		assert.Equal(t, "B", edge.From)
+	})
+}
+
+func TestGraphEdgeAttributes(t *testing.T) {
+	t.Run("Should update the weight and label of an edge", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		g.AddEdge("A", "B", 5)
+		assert.Nil(t, g.SetWeight("A", "B", 8))
+		assert.Nil(t, g.SetLabel("A", "B", "road"))
+
+		edge, _ := g.Edge("A", "B")
+		assert.Equal(t, 8, edge.Weight)
+		assert.Equal(t, "road", edge.Label)
+
+		assert.NotNil(t, g.SetWeight("B", "A", 1))
+		assert.NotNil(t, g.SetLabel("B", "A", "rail"))
+	})
+
+	t.Run("Should add a labelled edge in one call", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		assert.Nil(t, g.AddLabeledEdge("A", "B", 5, "road"))
+		assert.NotNil(t, g.AddLabeledEdge("B", "A", 2, "rail"))
+
+		edge, _ := g.Edge("B", "A")
+		assert.Equal(t, 5, edge.Weight)
+		assert.Equal(t, "road", edge.Label)
+		assert.Equal(t, 1, g.NumEdges())
+	})
+
+	t.Run("Should share attributes between both directions of an undirected edge", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddEdge("A", "B", 5)
+		g.SetWeight("B", "A", 3)
+		g.SetLabel("A", "B", "ferry")
+
+		edge, _ := g.Edge("A", "B")
+		assert.Equal(t, 3, edge.Weight)
+		edge, _ = g.Edge("B", "A")
+		assert.Equal(t, "ferry", edge.Label)
+	})
+}
+
+func TestGraphVertexAttributes(t *testing.T) {
+	t.Run("Should set and get the label of a vertex", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		g.AddVertex("A")
+		g.AddEdge("B", "C", 1)
+
+		label, err := g.VertexLabel("A")
+		assert.Nil(t, err)
+		assert.Equal(t, "", label)
+
+		assert.Nil(t, g.SetVertexLabel("A", "depot"))
+		assert.Nil(t, g.SetVertexLabel("C", "store"))
+		label, _ = g.VertexLabel("A")
+		assert.Equal(t, "depot", label)
+		label, _ = g.VertexLabel("C")
+		assert.Equal(t, "store", label)
+	})
+
+	t.Run("Should return an error for a vertex that is not in the graph", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[int, int]()
+		assert.NotNil(t, g.SetVertexLabel(1, "missing"))
+		_, err := g.VertexLabel(1)
+		assert.NotNil(t, err)
+		assert.False(t, g.HasVertex(1))
+	})
+
+	t.Run("Should drop the label when the vertex is removed", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[int, int]()
+		g.AddVertex(1)
+		g.SetVertexLabel(1, "old")
+		g.RemoveVertex(1)
+		g.AddVertex(1)
+
+		label, err := g.VertexLabel(1)
+		assert.Nil(t, err)
+		assert.Equal(t, "", label)
+	})
+}
+
+func TestGraphRemoveEdge(t *testing.T) {
+	t.Run("Should remove a directed edge and keep its vertices", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("B", "A", 1)
+		assert.Nil(t, g.RemoveEdge("A", "B"))
+		assert.NotNil(t, g.RemoveEdge("A", "B"))
+
+		assert.False(t, g.HasEdge("A", "B"))
+		assert.True(t, g.HasEdge("B", "A"))
+		assert.Equal(t, 1, g.NumEdges())
+		assert.Equal(t, 2, g.NumVertices())
+		assert.Equal(t, 0, g.InDegree("B"))
+	})
+
+	t.Run("Should remove an undirected edge from either end", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		assert.Nil(t, g.RemoveEdge("B", "A"))
+		assert.False(t, g.HasEdge("A", "B"))
+		assert.Equal(t, 0, g.NumEdges())
+		assert.Equal(t, 0, g.Degree("A"))
+	})
+}
+
+func TestGraphRemoveVertex(t *testing.T) {
+	t.Run("Should remove every edge into and out of a directed vertex", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("B", "C", 1)
+		g.AddEdge("C", "B", 1)
+		g.AddEdge("B", "B", 1)
+		g.AddEdge("A", "C", 1)
+
+		assert.Nil(t, g.RemoveVertex("B"))
+		assert.NotNil(t, g.RemoveVertex("B"))
+		assert.False(t, g.HasVertex("B"))
+		assert.Equal(t, 2, g.NumVertices())
+		assert.Equal(t, 1, g.NumEdges())
+		assert.Equal(t, 1, g.OutDegree("A"))
+		assert.Equal(t, 0, g.OutDegree("C"))
+		assert.Equal(t, 1, g.InDegree("C"))
+	})
+
+	t.Run("Should remove every edge incident to an undirected vertex", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[int, int]()
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(1, 3, 1)
+		g.AddEdge(1, 1, 1)
+		g.AddEdge(2, 3, 1)
+
+		assert.Nil(t, g.RemoveVertex(1))
+		assert.Equal(t, 2, g.NumVertices())
+		assert.Equal(t, 1, g.NumEdges())
+		assert.Equal(t, 1, g.Degree(2))
+		assert.Equal(t, 1, g.Degree(3))
+	})
+}
+
+func TestGraphIteration(t *testing.T) {
+	t.Run("Should yield the neighbors of a vertex with their weights", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("A", "C", 2)
+		g.AddEdge("C", "A", 3)
+
+		assert.Equal(t, map[string]int{"B": 1, "C": 2}, maps.Collect(g.Neighbors("A")))
+		assert.Empty(t, maps.Collect(g.Neighbors("B")))
+		assert.Empty(t, maps.Collect(g.Neighbors("Z")))
+	})
+
+	t.Run("Should yield the neighbors at both ends of undirected edges", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("C", "A", 2)
+
+		assert.Equal(t, map[string]int{"B": 1, "C": 2}, maps.Collect(g.Neighbors("A")))
+		assert.Equal(t, map[string]int{"A": 2}, maps.Collect(g.Neighbors("C")))
+	})
+
+	t.Run("Should yield every vertex", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddVertex("D")
+		g.AddEdge("A", "B", 1)
+		assert.Equal(t, []string{"A", "B", "D"}, slices.Sorted(g.Vertices()))
+	})
+
+	t.Run("Should yield each undirected edge once", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("B", "C", 2)
+		g.AddEdge("C", "C", 3)
+
+		weights := []int{}
+		for edge := range g.Edges() {
+			weights = append(weights, edge.Weight)
+		}
+		slices.Sort(weights)
+		assert.Equal(t, []int{1, 2, 3}, weights)
+	})
+
+	t.Run("Should stop when the consumer stops", func(t *testing.T) {
+		g := ds.NewDirectedGraph[int, int]()
+		for i := range 100 {
+			g.AddEdge(0, i+1, i)
+		}
+
+		count := 0
+		for range g.Neighbors(0) {
+			count++
+			if count == 5 {
+				break
+			}
+		}
+		assert.Equal(t, 5, count)
+	})
+}
+
+func TestGraphDegree(t *testing.T) {
+	t.Run("Should count in and out edges of a directed graph", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("A", "C", 1)
+		g.AddEdge("C", "A", 1)
+
+		assert.Equal(t, 2, g.OutDegree("A"))
+		assert.Equal(t, 1, g.InDegree("A"))
+		assert.Equal(t, 3, g.Degree("A"))
+		assert.Equal(t, 0, g.OutDegree("B"))
+		assert.Equal(t, 1, g.InDegree("B"))
+		assert.Equal(t, 0, g.Degree("Z"))
+	})
+
+	t.Run("Should count incident edges of an undirected graph", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("A", "C", 1)
+
+		assert.Equal(t, 2, g.Degree("A"))
+		assert.Equal(t, 2, g.InDegree("A"))
+		assert.Equal(t, 2, g.OutDegree("A"))
+		assert.Equal(t, 1, g.Degree("B"))
+	})
+	t.Run("Should count a self-loop once in a directed graph", func(t *testing.T) {
+		g := ds.NewDirectedGraph[string, int]()
+		g.AddEdge("A", "A", 1)
+		g.AddEdge("A", "B", 1)
+
+		assert.Equal(t, 2, g.NumEdges())
+		assert.Equal(t, 2, g.OutDegree("A"))
+		assert.Equal(t, 1, g.InDegree("A"))
+		assert.Equal(t, 2, g.Degree("A"))
+
+		assert.Nil(t, g.RemoveVertex("A"))
+		assert.Equal(t, 0, g.NumEdges())
+		assert.Equal(t, 0, g.Degree("A"))
+		assert.Equal(t, 0, g.InDegree("B"))
+	})
+
+	t.Run("Should count a self-loop once in an undirected graph", func(t *testing.T) {
+		g := ds.NewUndirectedGraph[string, int]()
+		g.AddEdge("A", "A", 1)
+		g.AddEdge("A", "B", 1)
+
+		assert.Equal(t, 2, g.NumEdges())
+		assert.Equal(t, 2, g.Degree("A"))
+		assert.Equal(t, 1, g.Degree("B"))
+
+		assert.Nil(t, g.RemoveVertex("A"))
+		assert.Equal(t, 0, g.NumEdges())
+		assert.Equal(t, 0, g.Degree("A"))
+		assert.Equal(t, 0, g.Degree("B"))
+	})
+}
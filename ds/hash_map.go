@@ -0,0 +1,205 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// hashMapEntry is a single key/value pair stored in a HashMap bucket. Entries are chained by
+// pointer, so that an entry's value can be replaced in place and the entry can be found again to be
+// removed from its chain.
+type hashMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// HashMap is a generic key/value hash table that handles collisions the same way as HashTable, by
+// chaining the entries that hash to a bucket together in a linked list.
+type HashMap[K comparable, V any] struct {
+	table  []*List[*hashMapEntry[K, V]]
+	len    int
+	config hashTableConfig
+}
+
+// NewHashMap returns an empty HashMap configured with the given options. Keys are hashed by the
+// configured Hasher (Dbj2Hash by default, which is also used if WithHasher is given a nil Hasher):
+// strings are hashed as they are and any other key is first converted to a string that identifies
+// its value. WithDuplicateMode does not apply to a HashMap, which stores a single value per key.
+func NewHashMap[K comparable, V any](opts ...HashTableOption) *HashMap[K, V] {
+	return &HashMap[K, V]{
+		table:  make([]*List[*hashMapEntry[K, V]], defaultHashTableCapacity),
+		len:    0,
+		config: newHashTableConfig(opts),
+	}
+}
+
+// NewStringHashMap returns an empty HashMap keyed by strings configured with the given options.
+func NewStringHashMap[V any](opts ...HashTableOption) *HashMap[string, V] {
+	return NewHashMap[string, V](opts...)
+}
+
+// Len returns the number of key/value pairs stored in the map.
+func (m HashMap[K, V]) Len() int {
+	return m.len
+}
+
+// Put associates the value with the given key, replacing any value previously stored under the key.
+// The HashMap will automatically resize if needed.
+func (m *HashMap[K, V]) Put(key K, value V) {
+	if entry := m.find(key); entry != nil {
+		entry.value = value
+		return
+	}
+
+	if float64(m.Len()+1) > float64(len(m.table))*m.config.maxLoadFactor {
+		m.resize(m.config.grownCapacity(len(m.table)))
+	}
+
+	addToChain(m.table, m.index(key), &hashMapEntry[K, V]{key: key, value: value})
+	m.len++
+}
+
+// Get returns the value stored under the given key; if the key is not found an error is returned.
+func (m HashMap[K, V]) Get(key K) (V, error) {
+	if entry := m.find(key); entry != nil {
+		return entry.value, nil
+	}
+
+	var empty V
+	return empty, errors.New("key not found")
+}
+
+// Contains returns true if a value is stored under the given key; else it returns false.
+func (m HashMap[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Delete removes the given key and its value from the map; if the key is not found an error is
+// returned. The HashMap will automatically shrink if needed.
+func (m *HashMap[K, V]) Delete(key K) error {
+	entry := m.find(key)
+	if entry == nil {
+		return errors.New("key not found")
+	}
+
+	index := m.index(key)
+	list := m.table[index]
+	if _, err := list.Remove(entry); err != nil {
+		return err
+	}
+
+	if list.Len() < 1 {
+		// drop empty buckets so they can be garbage collected
+		m.table[index] = nil
+	}
+	m.len--
+
+	if capacity := m.config.shrunkCapacity(len(m.table), m.len); capacity < len(m.table) {
+		m.resize(capacity)
+	}
+
+	return nil
+}
+
+// All returns an iterator over every key/value pair stored in the map, in no particular order. The
+// map must not be modified during iteration.
+func (m HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, list := range m.table {
+			if list == nil {
+				continue
+			}
+			for node := list.Head; node != nil; node = node.Next {
+				if !yield(node.Elem.key, node.Elem.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// hash returns the hash of the key computed by the map's Hasher.
+func (m HashMap[K, V]) hash(key K) uint {
+	return hashMapKey(m.config.hasher, key)
+}
+
+// hashMapKey returns the hash of the key computed by the given Hasher.
+func hashMapKey[K comparable](hasher Hasher, key K) uint {
+	return hasher.Hash(hashKey(key))
+}
+
+// index returns the bucket that the given key belongs in.
+func (m HashMap[K, V]) index(key K) uint {
+	return m.hash(key) % uint(len(m.table))
+}
+
+// find walks the bucket that the key hashes to and returns the entry with an equal key, or nil if
+// there is no such entry.
+func (m HashMap[K, V]) find(key K) *hashMapEntry[K, V] {
+	list := m.table[m.index(key)]
+	if list == nil {
+		return nil
+	}
+
+	for node := list.Head; node != nil; node = node.Next {
+		if node.Elem.key == key {
+			return node.Elem
+		}
+	}
+
+	return nil
+}
+
+// resize allocates a table with the given number of buckets and re-hashes every entry into it.
+func (m *HashMap[K, V]) resize(capacity int) {
+	m.table = rehashChains(m.table, capacity, func(entry *hashMapEntry[K, V]) uint {
+		return m.hash(entry.key)
+	})
+}
+
+// hashKey returns the string passed to a Hasher to hash the key, which is the same for keys that
+// compare equal. Strings are used as they are; numbers and booleans are formatted by value, with
+// positive and negative zero formatted alike; pointers and channels are identified by the address
+// they hold; any other key is formatted using its Go-syntax representation.
+func hashKey[K comparable](key K) string {
+	if s, ok := any(key).(string); ok {
+		return s
+	}
+
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(positiveZero(v.Float()), 'g', -1, 64)
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		c = complex(positiveZero(real(c)), positiveZero(imag(c)))
+		return strconv.FormatComplex(c, 'g', -1, 128)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return "0x" + strconv.FormatUint(uint64(v.Pointer()), 16)
+	}
+
+	return fmt.Sprintf("%#v", key)
+}
+
+// positiveZero returns f, turning negative zero (which compares equal to zero) into zero.
+func positiveZero(f float64) float64 {
+	if f == 0 {
+		return 0
+	}
+	return f
+}
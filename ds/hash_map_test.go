@@ -0,0 +1,235 @@
+package ds_test
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// collidingHasher sends every key to the same bucket so that chaining can be exercised.
+var collidingHasher = ds.WithHasher(ds.HasherFunc(func(string) uint {
+	return 7
+}))
+
+func TestNewHashMap(t *testing.T) {
+	t.Run("Should create an empty hash map", func(t *testing.T) {
+		m := ds.NewStringHashMap[int]()
+		assert.Equal(t, 0, m.Len())
+	})
+
+	t.Run("Should hash keys with the configured Hasher", func(t *testing.T) {
+		calls := 0
+		m := ds.NewHashMap[int, string](ds.WithHasher(ds.HasherFunc(func(s string) uint {
+			calls++
+			return ds.Dbj2Hash(s)
+		})))
+		m.Put(1, "one")
+
+		v, err := m.Get(1)
+		assert.Nil(t, err)
+		assert.Equal(t, "one", v)
+		assert.Greater(t, calls, 0)
+	})
+
+	t.Run("Should fall back to the default Hasher when given a nil Hasher", func(t *testing.T) {
+		m := ds.NewHashMap[int, string](ds.WithHasher(nil))
+		m.Put(1, "one")
+
+		v, err := m.Get(1)
+		assert.Nil(t, err)
+		assert.Equal(t, "one", v)
+	})
+}
+
+func TestHashMapPut(t *testing.T) {
+	t.Run("Should grow in size when a new key is added", func(t *testing.T) {
+		m := ds.NewStringHashMap[int]()
+		m.Put("a", 1)
+		m.Put("b", 2)
+		assert.Equal(t, 2, m.Len())
+	})
+
+	t.Run("Should replace the value of an existing key", func(t *testing.T) {
+		m := ds.NewStringHashMap[int]()
+		m.Put("a", 1)
+		m.Put("a", 2)
+		assert.Equal(t, 1, m.Len())
+
+		v, err := m.Get("a")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("Should keep all entries reachable after resizing", func(t *testing.T) {
+		m := ds.NewStringHashMap[int]()
+		for i := range 1000 {
+			m.Put(fmt.Sprintf("key-%d", i), i)
+		}
+		assert.Equal(t, 1000, m.Len())
+
+		for i := range 1000 {
+			v, err := m.Get(fmt.Sprintf("key-%d", i))
+			assert.Nil(t, err)
+			assert.Equal(t, i, v)
+		}
+	})
+}
+
+func TestHashMapGet(t *testing.T) {
+	t.Run("Get on an empty map", func(t *testing.T) {
+		m := ds.NewStringHashMap[int]()
+		v, err := m.Get("missing")
+		assert.Zero(t, v)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Get should compare keys within a bucket", func(t *testing.T) {
+		m := ds.NewHashMap[int, string](collidingHasher)
+		m.Put(1, "one")
+		m.Put(2, "two")
+		m.Put(3, "three")
+
+		v, err := m.Get(1)
+		assert.Nil(t, err)
+		assert.Equal(t, "one", v)
+		v, err = m.Get(2)
+		assert.Nil(t, err)
+		assert.Equal(t, "two", v)
+		v, err = m.Get(3)
+		assert.Nil(t, err)
+		assert.Equal(t, "three", v)
+
+		_, err = m.Get(4)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestHashMapKeys(t *testing.T) {
+	t.Run("Should find a key that compares equal to the stored key", func(t *testing.T) {
+		m := ds.NewHashMap[float64, string]()
+		m.Put(0, "zero")
+
+		v, err := m.Get(math.Copysign(0, -1))
+		assert.Nil(t, err)
+		assert.Equal(t, "zero", v)
+	})
+
+	t.Run("Should hash pointer keys by address", func(t *testing.T) {
+		type point struct{ x, y int }
+		p, q := &point{1, 2}, &point{1, 2}
+		m := ds.NewHashMap[*point, string]()
+		m.Put(p, "p")
+		// changing what the pointer refers to must not move the key
+		p.x = 10
+
+		assert.True(t, m.Contains(p))
+		assert.False(t, m.Contains(q))
+	})
+
+	t.Run("Should store struct keys", func(t *testing.T) {
+		type point struct{ x, y int }
+		m := ds.NewHashMap[point, string]()
+		for i := range 100 {
+			m.Put(point{i, -i}, fmt.Sprint(i))
+		}
+
+		for i := range 100 {
+			v, err := m.Get(point{i, -i})
+			assert.Nil(t, err)
+			assert.Equal(t, fmt.Sprint(i), v)
+		}
+		assert.False(t, m.Contains(point{1, 1}))
+	})
+}
+
+func TestHashMapContains(t *testing.T) {
+	t.Run("Should report whether a key is present", func(t *testing.T) {
+		m := ds.NewHashMap[int, string](collidingHasher)
+		m.Put(1, "one")
+		assert.True(t, m.Contains(1))
+		assert.False(t, m.Contains(2))
+	})
+}
+
+func TestHashMapDelete(t *testing.T) {
+	t.Run("Delete on an empty map", func(t *testing.T) {
+		m := ds.NewStringHashMap[int]()
+		err := m.Delete("missing")
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, m.Len())
+	})
+
+	t.Run("Delete from the middle of a bucket", func(t *testing.T) {
+		m := ds.NewHashMap[int, string](collidingHasher)
+		m.Put(1, "one")
+		m.Put(2, "two")
+		m.Put(3, "three")
+
+		err := m.Delete(2)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, m.Len())
+		assert.False(t, m.Contains(2))
+		assert.True(t, m.Contains(1))
+		assert.True(t, m.Contains(3))
+	})
+
+	t.Run("Should keep every entry reachable as the map shrinks", func(t *testing.T) {
+		m := ds.NewHashMap[int, int]()
+		for i := range 1000 {
+			m.Put(i, i)
+		}
+		for i := range 990 {
+			assert.Nil(t, m.Delete(i))
+		}
+
+		assert.Equal(t, 10, m.Len())
+		assert.Equal(t, map[int]int{990: 990, 991: 991, 992: 992, 993: 993, 994: 994, 995: 995,
+			996: 996, 997: 997, 998: 998, 999: 999}, maps.Collect(m.All()))
+	})
+
+	t.Run("Delete the head of a bucket", func(t *testing.T) {
+		m := ds.NewHashMap[int, string](collidingHasher)
+		m.Put(1, "one")
+		m.Put(2, "two")
+
+		err := m.Delete(2)
+		assert.Nil(t, err)
+		assert.False(t, m.Contains(2))
+		assert.True(t, m.Contains(1))
+
+		err = m.Delete(2)
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, m.Len())
+	})
+}
+
+func TestHashMapAll(t *testing.T) {
+	t.Run("Should yield every key/value pair", func(t *testing.T) {
+		m := ds.NewHashMap[int, string](collidingHasher)
+		m.Put(1, "one")
+		m.Put(2, "two")
+		m.Put(3, "three")
+
+		assert.Equal(t, map[int]string{1: "one", 2: "two", 3: "three"}, maps.Collect(m.All()))
+	})
+
+	t.Run("Should stop when the consumer stops", func(t *testing.T) {
+		m := ds.NewStringHashMap[int]()
+		for i := range 100 {
+			m.Put(fmt.Sprintf("key-%d", i), i)
+		}
+
+		count := 0
+		for range m.All() {
+			count++
+			if count == 5 {
+				break
+			}
+		}
+		assert.Equal(t, 5, count)
+	})
+}
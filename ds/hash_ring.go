@@ -0,0 +1,212 @@
+package ds
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+)
+
+const defaultHashRingVirtualNodes = 100
+
+// ringPoint is a single virtual node on a HashRing.
+type ringPoint struct {
+	hash   uint
+	member string
+}
+
+// HashRingOption configures a HashRing at construction time.
+type HashRingOption func(*HashRing)
+
+// WithRingHasher sets the hash function used to place members and keys on the ring. Nil hashers are
+// ignored.
+func WithRingHasher(h Hasher) HashRingOption {
+	return func(r *HashRing) {
+		if h != nil {
+			r.hasher = h
+		}
+	}
+}
+
+// WithVirtualNodes sets the number of virtual nodes placed on the ring per unit of member weight.
+// More virtual nodes spread keys more evenly at the cost of memory. Values less than 1 are ignored.
+func WithVirtualNodes(n int) HashRingOption {
+	return func(r *HashRing) {
+		if n > 0 {
+			r.virtualNodes = n
+		}
+	}
+}
+
+// HashRing implements [consistent hashing][0]. Keys and members are hashed onto the same circular
+// range and a key belongs to the first member found moving clockwise from the key's position. When
+// a member joins or leaves only the keys in the arcs it gains or gives up change owner.
+//
+// Each member is placed on the ring many times (as virtual nodes) in proportion to its weight so
+// that keys are spread evenly. Because the ring relies on hashes being spread uniformly across the
+// whole range of uint, the default hasher is xxHash64; Dbj2Hash (or any other Hasher) can be used
+// via WithRingHasher, but djb2 hashes of short strings are small numbers that cluster at the start
+// of the ring.
+//
+// [0]: https://en.wikipedia.org/wiki/Consistent_hashing
+type HashRing struct {
+	points       []ringPoint
+	members      map[string]int
+	virtualNodes int
+	hasher       Hasher
+}
+
+// NewHashRing returns an empty HashRing configured with the given options.
+func NewHashRing(opts ...HashRingOption) *HashRing {
+	r := &HashRing{
+		points:       nil,
+		members:      make(map[string]int),
+		virtualNodes: defaultHashRingVirtualNodes,
+		hasher:       XXHash64Hasher{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Len returns the number of members on the ring.
+func (r HashRing) Len() int {
+	return len(r.members)
+}
+
+// Members returns the members on the ring in sorted order.
+func (r HashRing) Members() []string {
+	members := make([]string, 0, len(r.members))
+	for m := range r.members {
+		members = append(members, m)
+	}
+	slices.Sort(members)
+
+	return members
+}
+
+// Weight returns the weight of the given member; if the member is not on the ring an error is
+// returned.
+func (r HashRing) Weight(member string) (int, error) {
+	weight, ok := r.members[member]
+	if !ok {
+		return 0, errors.New("member not found")
+	}
+
+	return weight, nil
+}
+
+// Add places a member on the ring with the given weight; a member with weight 2 is expected to own
+// twice as many keys as a member with weight 1. An error is returned if the weight is less than 1
+// or the member is already on the ring.
+func (r *HashRing) Add(member string, weight int) error {
+	if weight < 1 {
+		return errors.New("weight must be at least 1")
+	}
+	if _, exists := r.members[member]; exists {
+		return errors.New("member already exists")
+	}
+
+	r.members[member] = weight
+	for i := range weight * r.virtualNodes {
+		r.points = append(r.points, ringPoint{
+			hash:   r.hasher.Hash(member + "#" + strconv.Itoa(i)),
+			member: member,
+		})
+	}
+	slices.SortFunc(r.points, compareRingPoints)
+
+	return nil
+}
+
+// Remove takes a member off the ring; if the member is not on the ring an error is returned.
+func (r *HashRing) Remove(member string) error {
+	if _, exists := r.members[member]; !exists {
+		return errors.New("member not found")
+	}
+
+	delete(r.members, member)
+	r.points = slices.DeleteFunc(r.points, func(p ringPoint) bool {
+		return p.member == member
+	})
+
+	return nil
+}
+
+// Get returns the member that owns the given key; if the ring is empty an error is returned.
+func (r HashRing) Get(key string) (string, error) {
+	if len(r.points) == 0 {
+		return "", errors.New("ring is empty")
+	}
+
+	return r.points[r.search(key)].member, nil
+}
+
+// GetN returns up to n distinct members for the given key, starting with the key's owner and
+// continuing clockwise around the ring, which makes it suitable for choosing where to place
+// replicas. Fewer than n members are returned if the ring does not have n members. An error is
+// returned if the ring is empty or n is less than 1.
+func (r HashRing) GetN(key string, n int) ([]string, error) {
+	if len(r.points) == 0 {
+		return nil, errors.New("ring is empty")
+	}
+	if n < 1 {
+		return nil, errors.New("n must be at least 1")
+	}
+
+	n = min(n, len(r.members))
+	members := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+
+	start := r.search(key)
+	for i := 0; i < len(r.points) && len(members) < n; i++ {
+		p := r.points[(start+i)%len(r.points)]
+		if _, ok := seen[p.member]; !ok {
+			seen[p.member] = struct{}{}
+			members = append(members, p.member)
+		}
+	}
+
+	return members, nil
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// search returns the index of the first virtual node at or clockwise of the key's position, wrapping
+// around to the start of the ring.
+func (r HashRing) search(key string) int {
+	hash := r.hasher.Hash(key)
+	i, _ := slices.BinarySearchFunc(r.points, hash, func(p ringPoint, h uint) int {
+		if p.hash < h {
+			return -1
+		} else if p.hash > h {
+			return 1
+		}
+		return 0
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return i
+}
+
+// compareRingPoints orders virtual nodes by hash, breaking ties by member so that the ring layout
+// does not depend on the order members were added.
+func compareRingPoints(a, b ringPoint) int {
+	if a.hash < b.hash {
+		return -1
+	} else if a.hash > b.hash {
+		return 1
+	}
+
+	if a.member < b.member {
+		return -1
+	} else if a.member > b.member {
+		return 1
+	}
+	return 0
+}
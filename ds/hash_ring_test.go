@@ -0,0 +1,232 @@
+package ds_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func ringKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+	return keys
+}
+
+// ringOwners returns the member that owns each of the given keys.
+func ringOwners(t *testing.T, r *ds.HashRing, keys []string) map[string]string {
+	owners := make(map[string]string, len(keys))
+	for _, k := range keys {
+		m, err := r.Get(k)
+		assert.Nil(t, err)
+		owners[k] = m
+	}
+	return owners
+}
+
+func newTestRing(t *testing.T, members int, opts ...ds.HashRingOption) *ds.HashRing {
+	r := ds.NewHashRing(opts...)
+	for i := range members {
+		assert.Nil(t, r.Add(fmt.Sprintf("node-%d", i), 1))
+	}
+	return r
+}
+
+func TestNewHashRing(t *testing.T) {
+	t.Run("Should create an empty ring", func(t *testing.T) {
+		r := ds.NewHashRing()
+		assert.Equal(t, 0, r.Len())
+
+		_, err := r.Get("key")
+		assert.NotNil(t, err)
+		_, err = r.GetN("key", 2)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestHashRingAddRemove(t *testing.T) {
+	t.Run("Should reject invalid and duplicate members", func(t *testing.T) {
+		r := ds.NewHashRing()
+		assert.NotNil(t, r.Add("node-a", 0))
+		assert.Nil(t, r.Add("node-a", 2))
+		assert.NotNil(t, r.Add("node-a", 1))
+		assert.Equal(t, 1, r.Len())
+
+		w, err := r.Weight("node-a")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, w)
+	})
+
+	t.Run("Should reject removing an unknown member", func(t *testing.T) {
+		r := ds.NewHashRing()
+		assert.NotNil(t, r.Remove("node-a"))
+	})
+
+	t.Run("Should track members", func(t *testing.T) {
+		r := newTestRing(t, 3)
+		assert.Equal(t, []string{"node-0", "node-1", "node-2"}, r.Members())
+
+		assert.Nil(t, r.Remove("node-1"))
+		assert.Equal(t, []string{"node-0", "node-2"}, r.Members())
+		_, err := r.Weight("node-1")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("A single member should own every key", func(t *testing.T) {
+		r := newTestRing(t, 1)
+		for _, k := range ringKeys(100) {
+			m, err := r.Get(k)
+			assert.Nil(t, err)
+			assert.Equal(t, "node-0", m)
+		}
+	})
+}
+
+func TestHashRingRedistribution(t *testing.T) {
+	keys := ringKeys(20_000)
+
+	t.Run("Adding a member should only move keys to the new member", func(t *testing.T) {
+		r := newTestRing(t, 10)
+		before := ringOwners(t, r, keys)
+
+		assert.Nil(t, r.Add("node-new", 1))
+		after := ringOwners(t, r, keys)
+
+		moved := 0
+		for _, k := range keys {
+			if before[k] != after[k] {
+				moved++
+				assert.Equal(t, "node-new", after[k])
+			}
+		}
+		// the new member should take roughly 1/11th of the keys
+		fraction := float64(moved) / float64(len(keys))
+		assert.InDelta(t, 1.0/11, fraction, 0.03)
+	})
+
+	t.Run("Removing a member should only move the keys it owned", func(t *testing.T) {
+		r := newTestRing(t, 10)
+		before := ringOwners(t, r, keys)
+
+		assert.Nil(t, r.Remove("node-3"))
+		after := ringOwners(t, r, keys)
+
+		moved := 0
+		for _, k := range keys {
+			if before[k] != after[k] {
+				moved++
+				assert.Equal(t, "node-3", before[k])
+			}
+			assert.NotEqual(t, "node-3", after[k])
+		}
+		fraction := float64(moved) / float64(len(keys))
+		assert.InDelta(t, 1.0/10, fraction, 0.03)
+	})
+
+	t.Run("Removing and re-adding a member should restore the original owners", func(t *testing.T) {
+		r := newTestRing(t, 5)
+		before := ringOwners(t, r, keys)
+
+		assert.Nil(t, r.Remove("node-2"))
+		assert.Nil(t, r.Add("node-2", 1))
+		assert.Equal(t, before, ringOwners(t, r, keys))
+	})
+
+	t.Run("Keys should be spread evenly across members", func(t *testing.T) {
+		r := newTestRing(t, 10)
+		counts := make(map[string]int)
+		for _, m := range ringOwners(t, r, keys) {
+			counts[m]++
+		}
+
+		expected := len(keys) / 10
+		for m, c := range counts {
+			assert.InDeltaf(t, expected, c, float64(expected)*0.35, "member %s is unbalanced", m)
+		}
+	})
+
+	t.Run("Members should own keys in proportion to their weight", func(t *testing.T) {
+		r := ds.NewHashRing()
+		assert.Nil(t, r.Add("small", 1))
+		assert.Nil(t, r.Add("large", 3))
+
+		counts := make(map[string]int)
+		for _, m := range ringOwners(t, r, keys) {
+			counts[m]++
+		}
+		ratio := float64(counts["large"]) / float64(counts["small"])
+		assert.InDelta(t, 3.0, ratio, 0.75)
+	})
+}
+
+func TestHashRingGetN(t *testing.T) {
+	t.Run("Should return distinct members starting with the owner", func(t *testing.T) {
+		r := newTestRing(t, 5)
+		for _, k := range ringKeys(100) {
+			owner, _ := r.Get(k)
+			replicas, err := r.GetN(k, 3)
+			assert.Nil(t, err)
+			assert.Len(t, replicas, 3)
+			assert.Equal(t, owner, replicas[0])
+			assert.NotEqual(t, replicas[0], replicas[1])
+			assert.NotEqual(t, replicas[1], replicas[2])
+			assert.NotEqual(t, replicas[0], replicas[2])
+		}
+	})
+
+	t.Run("Should return every member when n exceeds the ring size", func(t *testing.T) {
+		r := newTestRing(t, 3)
+		replicas, err := r.GetN("key", 10)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"node-0", "node-1", "node-2"}, replicas)
+	})
+
+	t.Run("Should reject n less than 1", func(t *testing.T) {
+		r := newTestRing(t, 3)
+		_, err := r.GetN("key", 0)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Replica sets should change minimally when a member is added", func(t *testing.T) {
+		r := newTestRing(t, 10)
+		keys := ringKeys(5000)
+		before := make(map[string][]string)
+		for _, k := range keys {
+			before[k], _ = r.GetN(k, 3)
+		}
+
+		assert.Nil(t, r.Add("node-new", 1))
+		for _, k := range keys {
+			after, _ := r.GetN(k, 3)
+			// dropping the new member from the replica set should leave a prefix of the old set
+			var kept []string
+			for _, m := range after {
+				if m != "node-new" {
+					kept = append(kept, m)
+				}
+			}
+			assert.Equal(t, before[k][:len(kept)], kept)
+		}
+	})
+}
+
+func TestHashRingHashers(t *testing.T) {
+	t.Run("Should work with a pluggable hasher", func(t *testing.T) {
+		r := newTestRing(t, 4, ds.WithRingHasher(ds.FNV1aHasher{}), ds.WithVirtualNodes(50))
+		counts := make(map[string]int)
+		for _, m := range ringOwners(t, r, ringKeys(4000)) {
+			counts[m]++
+		}
+		assert.Len(t, counts, 4)
+	})
+
+	t.Run("Should accept Dbj2Hash", func(t *testing.T) {
+		r := newTestRing(t, 4, ds.WithRingHasher(ds.Dbj2Hasher))
+		m, err := r.Get("key-1")
+		assert.Nil(t, err)
+		assert.Contains(t, r.Members(), m)
+	})
+}
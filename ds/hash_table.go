@@ -1,26 +1,150 @@
 package ds
 
-import "errors"
+import (
+	"errors"
+	"iter"
+	"math"
+)
 
+// Dbj2Hash implements Daniel J. Bernstein's djb2 hash function. The input is hashed one byte at a
+// time so that every byte of a multi-byte (non-ASCII) character contributes to the hash.
 func Dbj2Hash(input string) uint {
 	var hash uint = 5381
 
-	for _, char := range input {
-		hash = (hash << 5) + hash + uint(byte(char))
+	for i := 0; i < len(input); i++ {
+		hash = (hash << 5) + hash + uint(input[i])
 	}
 
 	return hash
 }
 
+const (
+	defaultHashTableCapacity      = 10
+	defaultHashTableMaxLoadFactor = 0.5
+	defaultHashTableGrowthFactor  = 2.0
+)
+
+// DuplicateMode determines how a hash table treats an element that is added more than once.
+type DuplicateMode int
+
+const (
+	// DuplicatesAllowed stores every occurrence of an element that is added, so the table behaves as
+	// a multiset.
+	DuplicatesAllowed DuplicateMode = iota
+	// DuplicatesRejected stores a single occurrence of each element, so the table behaves as a set;
+	// adding an element that is already in the table has no effect.
+	DuplicatesRejected
+)
+
+// hashTableConfig holds the tunable parameters shared by the hash table implementations.
+type hashTableConfig struct {
+	maxLoadFactor float64
+	growthFactor  float64
+	mode          DuplicateMode
+	hasher        Hasher
+}
+
+func newHashTableConfig(opts []HashTableOption) hashTableConfig {
+	cfg := hashTableConfig{
+		maxLoadFactor: defaultHashTableMaxLoadFactor,
+		growthFactor:  defaultHashTableGrowthFactor,
+		mode:          DuplicatesAllowed,
+		hasher:        Dbj2Hasher,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// HashTableOption configures a hash table at construction time.
+type HashTableOption func(*hashTableConfig)
+
+// WithMaxLoadFactor sets the ratio of elements to buckets that, once exceeded, causes the table to
+// grow. The table shrinks again once the load factor drops below a quarter of this value. Values
+// less than or equal to 0 are ignored.
+func WithMaxLoadFactor(f float64) HashTableOption {
+	return func(cfg *hashTableConfig) {
+		if f > 0 {
+			cfg.maxLoadFactor = f
+		}
+	}
+}
+
+// WithGrowthFactor sets the multiplier applied to the number of buckets when the table grows (and
+// the divisor applied when it shrinks). Values less than or equal to 1 are ignored.
+func WithGrowthFactor(f float64) HashTableOption {
+	return func(cfg *hashTableConfig) {
+		if f > 1 {
+			cfg.growthFactor = f
+		}
+	}
+}
+
+// WithDuplicateMode sets whether the table stores every occurrence of an element
+// (DuplicatesAllowed, the default) or only a single occurrence (DuplicatesRejected).
+func WithDuplicateMode(mode DuplicateMode) HashTableOption {
+	return func(cfg *hashTableConfig) {
+		cfg.mode = mode
+	}
+}
+
+// WithHasher sets the hash function used to place elements in the table; by default Dbj2Hash is
+// used. Nil hashers are ignored.
+func WithHasher(h Hasher) HashTableOption {
+	return func(cfg *hashTableConfig) {
+		if h != nil {
+			cfg.hasher = h
+		}
+	}
+}
+
+// HashTableStats describes how elements are currently distributed across the buckets of a
+// HashTable.
+type HashTableStats struct {
+	// Buckets is the total number of buckets in the table
+	Buckets int
+	// UsedBuckets is the number of buckets holding at least one element
+	UsedBuckets int
+	// LongestChain is the number of elements in the fullest bucket
+	LongestChain int
+	// LoadFactor is the ratio of elements to buckets
+	LoadFactor float64
+}
+
+// StringHashTable is the interface shared by the hash table implementations in this package that
+// store strings.
+type StringHashTable interface {
+	// Len returns the number of elements stored in the hash table
+	Len() int
+	// Cap returns the number of buckets or slots in the hash table
+	Cap() int
+	// Add adds an element to the hash table
+	Add(s string)
+	// Remove removes a single occurrence of the element from the hash table
+	Remove(s string) error
+	// Contains returns true if the element is in the hash table
+	Contains(s string) bool
+	// All returns an iterator over every element stored in the hash table
+	All() iter.Seq[string]
+}
+
+// HashTable is a hash table of strings that handles collisions by chaining colliding elements
+// together in a linked list per bucket.
 type HashTable struct {
-	table []*List[string]
-	len   int
+	table  []*List[string]
+	len    int
+	config hashTableConfig
 }
 
-func NewHashTable() *HashTable {
+// NewHashTable returns an empty hash table configured with the given options.
+func NewHashTable(opts ...HashTableOption) *HashTable {
 	return &HashTable{
-		table: make([]*List[string], 10),
-		len:   0,
+		table:  make([]*List[string], defaultHashTableCapacity),
+		len:    0,
+		config: newHashTableConfig(opts),
 	}
 }
 
@@ -29,31 +153,99 @@ func (ht HashTable) Len() int {
 	return ht.len
 }
 
-// Len returns the capacity of the hash table
+// Cap returns the number of buckets in the hash table
 func (ht HashTable) Cap() int {
-	return cap(ht.table)
+	return len(ht.table)
 }
 
-// Add adds an element to the hash table. The HashTable will automatically resize if needed.
-func (ht *HashTable) Add(s string) {
-	hash := Dbj2Hash(s)
-	capacity := len(ht.table)
+// Stats returns a snapshot of the bucket distribution of the hash table.
+func (ht HashTable) Stats() HashTableStats {
+	stats := HashTableStats{
+		Buckets:    len(ht.table),
+		LoadFactor: float64(ht.len) / float64(len(ht.table)),
+	}
 
-	if (ht.Len() + 1) > (capacity / 2) {
-		ht.resize()
-		capacity = len(ht.table)
+	for _, list := range ht.table {
+		if list == nil || list.Len() < 1 {
+			continue
+		}
+		stats.UsedBuckets++
+		stats.LongestChain = max(stats.LongestChain, list.Len())
 	}
 
-	index := hash % uint(capacity)
+	return stats
+}
 
-	if ht.table[index] == nil {
-		ht.table[index] = NewList[string]()
+// Add adds an element to the hash table. If duplicates are rejected, adding an element that is
+// already in the table has no effect. The HashTable will automatically resize if needed.
+func (ht *HashTable) Add(s string) {
+	if ht.config.mode == DuplicatesRejected && ht.Contains(s) {
+		return
 	}
 
-	ht.table[index].AddHead(s)
+	if float64(ht.Len()+1) > float64(len(ht.table))*ht.config.maxLoadFactor {
+		ht.resize(ht.config.grownCapacity(len(ht.table)))
+	}
+
+	index := ht.config.hasher.Hash(s) % uint(len(ht.table))
+	addToChain(ht.table, index, s)
 	ht.len++
 }
 
+// Remove removes a single occurrence of the element from the hash table; if the element is not found
+// an error is returned. The HashTable will automatically shrink if needed.
+func (ht *HashTable) Remove(s string) error {
+	index := ht.config.hasher.Hash(s) % uint(len(ht.table))
+	list := ht.table[index]
+
+	if list == nil {
+		return errors.New("element not found")
+	}
+
+	if _, err := list.Remove(s); err != nil {
+		return err
+	}
+
+	if list.Len() < 1 {
+		// drop empty buckets so they can be garbage collected
+		ht.table[index] = nil
+	}
+	ht.len--
+
+	if capacity := ht.config.shrunkCapacity(len(ht.table), ht.len); capacity < len(ht.table) {
+		ht.resize(capacity)
+	}
+
+	return nil
+}
+
+// Contains returns true if the element is in the hash table; else it returns false.
+func (ht HashTable) Contains(s string) bool {
+	list := ht.table[ht.config.hasher.Hash(s)%uint(len(ht.table))]
+
+	return list != nil && list.Contains(s)
+}
+
+// All returns an iterator over every element stored in the hash table, in no particular order. If
+// duplicates are allowed each occurrence of an element is yielded. The table must not be modified
+// during iteration.
+func (ht HashTable) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, list := range ht.table {
+			if list == nil {
+				continue
+			}
+			for node := list.Head; node != nil; node = node.Next {
+				if !yield(node.Elem) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Get returns the most recently added element in the bucket that the given hash maps to. The hash
+// must have been computed using the table's Hasher.
 func (ht HashTable) Get(hash uint) (string, error) {
 	index := hash % uint(len(ht.table))
 	list := ht.table[int(index)]
@@ -65,26 +257,75 @@ func (ht HashTable) Get(hash uint) (string, error) {
 	return list.Head.Elem, nil
 }
 
-func moveTableList(table []*List[string], list *List[string]) {
-	// All items in the list have the same hash so we can calculate the hash using the first element
-	// in the list
-	hash := Dbj2Hash(list.Head.Elem)
-	capacity := len(table)
-	// Add the list at the proper index in the underlying slice
-	index := hash % uint(capacity)
-	table[index] = list
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// grownCapacity returns the number of buckets a chained table with the given number of buckets
+// should have after growing.
+func (cfg hashTableConfig) grownCapacity(capacity int) int {
+	return max(int(math.Ceil(float64(capacity)*cfg.growthFactor)), capacity+1)
 }
 
-func (ht *HashTable) resize() {
-	// Resize the underlying slice
-	newTable := make([]*List[string], cap(ht.table)*2)
-	// re-hash all items in the table
-	for _, list := range ht.table {
-		// Add all non-null lists to the new table
-		if list != nil {
-			moveTableList(newTable, list)
-		}
+// shrunkCapacity returns the number of buckets a chained table with the given number of buckets
+// should have after a removal leaves it holding size elements; if the load factor is still
+// acceptable the current capacity is returned.
+func (cfg hashTableConfig) shrunkCapacity(capacity, size int) int {
+	if capacity <= defaultHashTableCapacity {
+		return capacity
+	}
+
+	if float64(size) >= float64(capacity)*cfg.maxLoadFactor/4 {
+		return capacity
 	}
+
+	// Undoing a single growth step is not enough with a large growth factor, since the table would
+	// then already be over the max load factor and grow again on the next Add
+	shrunk := max(
+		int(float64(capacity)/cfg.growthFactor),
+		shrinkFloor(size, cfg),
+		defaultHashTableCapacity,
+	)
+
+	return min(shrunk, capacity)
+}
+
+// shrinkFloor returns the fewest buckets (or slots) a table holding size elements may shrink to,
+// which leaves the table at no more than half of its max load factor. The headroom means that a
+// table that has just shrunk must double in size before it grows again, so alternating Add and
+// Remove calls cannot make it repeatedly resize.
+func shrinkFloor(size int, cfg hashTableConfig) int {
+	return int(math.Ceil(2 * float64(size) / cfg.maxLoadFactor))
+}
+
+// resize allocates a table with the given number of buckets and re-hashes every element into it.
+func (ht *HashTable) resize(capacity int) {
 	// Update the underlying hash table slice to the newly allocated one
-	ht.table = newTable
+	ht.table = rehashChains(ht.table, capacity, ht.config.hasher.Hash)
+}
+
+// rehashChains returns a table with the given number of buckets holding every element chained in
+// the given table. Elements are moved individually because two elements that shared a bucket in
+// the old table may belong in different buckets in the new one.
+func rehashChains[E comparable](table []*List[E], capacity int, hash func(E) uint) []*List[E] {
+	newTable := make([]*List[E], capacity)
+
+	for _, list := range table {
+		if list == nil {
+			continue
+		}
+		for node := list.Head; node != nil; node = node.Next {
+			addToChain(newTable, hash(node.Elem)%uint(capacity), node.Elem)
+		}
+	}
+
+	return newTable
+}
+
+// addToChain adds the element to the head of the chain in the bucket at the given index, creating
+// the chain if the bucket is empty.
+func addToChain[E comparable](table []*List[E], index uint, elem E) {
+	if table[index] == nil {
+		table[index] = NewList[E]()
+	}
+	table[index].AddHead(elem)
 }
@@ -0,0 +1,78 @@
+package ds_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+)
+
+var hashTableBenchmarks = []struct {
+	name     string
+	newTable func() ds.StringHashTable
+}{
+	{"Chaining", func() ds.StringHashTable { return ds.NewHashTable() }},
+	{"LinearProbing", func() ds.StringHashTable { return ds.NewLinearProbingHashTable() }},
+	{"QuadraticProbing", func() ds.StringHashTable { return ds.NewQuadraticProbingHashTable() }},
+	{"RobinHood", func() ds.StringHashTable { return ds.NewRobinHoodHashTable() }},
+}
+
+func benchmarkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("benchmark-key-%d", i)
+	}
+	return keys
+}
+
+func BenchmarkHashTableAdd(b *testing.B) {
+	keys := benchmarkKeys(10_000)
+
+	for _, bm := range hashTableBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				h := bm.newTable()
+				for _, k := range keys {
+					h.Add(k)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHashTableAddRemove(b *testing.B) {
+	keys := benchmarkKeys(10_000)
+
+	for _, bm := range hashTableBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				h := bm.newTable()
+				for _, k := range keys {
+					h.Add(k)
+				}
+				for _, k := range keys {
+					_ = h.Remove(k)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHashTableContains(b *testing.B) {
+	keys := benchmarkKeys(10_000)
+
+	for _, bm := range hashTableBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			h := bm.newTable()
+			for _, k := range keys {
+				h.Add(k)
+			}
+			b.ResetTimer()
+			for i := range b.N {
+				h.Contains(keys[i%len(keys)])
+			}
+		})
+	}
+}
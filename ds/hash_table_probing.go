@@ -0,0 +1,246 @@
+package ds
+
+import (
+	"errors"
+	"iter"
+	"math"
+)
+
+const (
+	// defaultOpenAddressingCapacity is the initial number of slots in an open-addressing table. Slot
+	// counts are always a power of two so that probe sequences can wrap around using a bit mask.
+	defaultOpenAddressingCapacity = 16
+	// maxOpenAddressingLoadFactor caps the configured max load factor; an open-addressing table must
+	// always keep some empty slots or probe sequences would never terminate.
+	maxOpenAddressingLoadFactor = 0.9
+)
+
+type slotState uint8
+
+const (
+	slotEmpty slotState = iota
+	slotOccupied
+	slotDeleted
+)
+
+// probingSlot is a single slot in a probing hash table. Removed elements leave behind a 'deleted'
+// tombstone so that probe sequences passing through the slot are not cut short.
+type probingSlot struct {
+	elem  string
+	hash  uint
+	state slotState
+}
+
+// probingHashTable is an open-addressing hash table that resolves collisions by following a probe
+// sequence; the exported linear and quadratic variants differ only in their probe function.
+type probingHashTable struct {
+	slots   []probingSlot
+	len     int
+	deleted int
+	// probe returns the offset from the home slot of the i-th slot in a probe sequence
+	probe  func(i int) uint
+	config hashTableConfig
+}
+
+func newProbingHashTable(probe func(i int) uint, opts []HashTableOption) probingHashTable {
+	cfg := newHashTableConfig(opts)
+	cfg.maxLoadFactor = min(cfg.maxLoadFactor, maxOpenAddressingLoadFactor)
+
+	return probingHashTable{
+		slots:  make([]probingSlot, defaultOpenAddressingCapacity),
+		probe:  probe,
+		config: cfg,
+	}
+}
+
+// Len returns the number of elements stored in the hash table
+func (ht probingHashTable) Len() int {
+	return ht.len
+}
+
+// Cap returns the number of slots in the hash table
+func (ht probingHashTable) Cap() int {
+	return len(ht.slots)
+}
+
+// Add adds an element to the hash table. If duplicates are rejected, adding an element that is
+// already in the table has no effect. The table will automatically resize if needed.
+func (ht *probingHashTable) Add(s string) {
+	if ht.config.mode == DuplicatesRejected && ht.Contains(s) {
+		return
+	}
+
+	limit := float64(len(ht.slots)) * ht.config.maxLoadFactor
+
+	if float64(ht.len+1) > limit {
+		ht.resize(growOpenAddressingCapacity(len(ht.slots), ht.config))
+	} else if float64(ht.len+ht.deleted+1) > limit {
+		// the table is mostly tombstones; rebuild it at the same size to clear them out
+		ht.resize(len(ht.slots))
+	}
+
+	ht.insert(s, ht.config.hasher.Hash(s))
+	ht.len++
+}
+
+// Remove removes a single occurrence of the element from the hash table; if the element is not found
+// an error is returned. The table will automatically shrink if needed.
+func (ht *probingHashTable) Remove(s string) error {
+	index, ok := ht.find(s, ht.config.hasher.Hash(s))
+	if !ok {
+		return errors.New("element not found")
+	}
+
+	ht.slots[index] = probingSlot{state: slotDeleted}
+	ht.len--
+	ht.deleted++
+
+	if capacity := shrinkOpenAddressingCapacity(len(ht.slots), ht.len, ht.config); capacity < len(ht.slots) {
+		ht.resize(capacity)
+	}
+
+	return nil
+}
+
+// Contains returns true if the element is in the hash table; else it returns false.
+func (ht probingHashTable) Contains(s string) bool {
+	_, ok := ht.find(s, ht.config.hasher.Hash(s))
+	return ok
+}
+
+// All returns an iterator over every element stored in the hash table, in no particular order. If
+// duplicates are allowed each occurrence of an element is yielded. The table must not be modified
+// during iteration.
+func (ht probingHashTable) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, slot := range ht.slots {
+			if slot.state == slotOccupied && !yield(slot.elem) {
+				return
+			}
+		}
+	}
+}
+
+// insert places the element in the first free slot of its probe sequence.
+func (ht *probingHashTable) insert(s string, hash uint) {
+	mask := uint(len(ht.slots) - 1)
+
+	for i := 0; ; i++ {
+		index := (hash + ht.probe(i)) & mask
+		switch ht.slots[index].state {
+		case slotDeleted:
+			ht.deleted--
+			fallthrough
+		case slotEmpty:
+			ht.slots[index] = probingSlot{elem: s, hash: hash, state: slotOccupied}
+			return
+		}
+	}
+}
+
+// find follows the probe sequence of the element and returns the index of the slot holding it.
+func (ht probingHashTable) find(s string, hash uint) (int, bool) {
+	mask := uint(len(ht.slots) - 1)
+
+	for i := 0; i < len(ht.slots); i++ {
+		index := (hash + ht.probe(i)) & mask
+		slot := ht.slots[index]
+		if slot.state == slotEmpty {
+			// the element would have been placed here if it were in the table
+			return 0, false
+		}
+		if slot.state == slotOccupied && slot.hash == hash && slot.elem == s {
+			return int(index), true
+		}
+	}
+
+	return 0, false
+}
+
+// resize allocates a table with the given number of slots and re-inserts every element, discarding
+// any tombstones.
+func (ht *probingHashTable) resize(capacity int) {
+	old := ht.slots
+	ht.slots = make([]probingSlot, capacity)
+	ht.deleted = 0
+
+	for _, slot := range old {
+		if slot.state == slotOccupied {
+			ht.insert(slot.elem, slot.hash)
+		}
+	}
+}
+
+// LinearProbingHashTable is an open-addressing hash table that resolves collisions by checking the
+// slots following the element's home slot one at a time.
+type LinearProbingHashTable struct {
+	probingHashTable
+}
+
+// NewLinearProbingHashTable returns an empty linear probing hash table configured with the given
+// options.
+func NewLinearProbingHashTable(opts ...HashTableOption) *LinearProbingHashTable {
+	return &LinearProbingHashTable{
+		probingHashTable: newProbingHashTable(linearProbe, opts),
+	}
+}
+
+// QuadraticProbingHashTable is an open-addressing hash table that resolves collisions by checking
+// slots at triangular-number offsets (1, 3, 6, 10, ...) from the element's home slot, which breaks
+// up the clusters that form under linear probing.
+type QuadraticProbingHashTable struct {
+	probingHashTable
+}
+
+// NewQuadraticProbingHashTable returns an empty quadratic probing hash table configured with the
+// given options.
+func NewQuadraticProbingHashTable(opts ...HashTableOption) *QuadraticProbingHashTable {
+	return &QuadraticProbingHashTable{
+		probingHashTable: newProbingHashTable(quadraticProbe, opts),
+	}
+}
+
+func linearProbe(i int) uint {
+	return uint(i)
+}
+
+// quadraticProbe uses triangular numbers, which are guaranteed to visit every slot of a table whose
+// size is a power of two.
+func quadraticProbe(i int) uint {
+	return uint(i * (i + 1) / 2)
+}
+
+// growOpenAddressingCapacity returns the number of slots an open-addressing table should have after
+// growing; the result is always a power of two.
+func growOpenAddressingCapacity(capacity int, cfg hashTableConfig) int {
+	return max(nextPowerOfTwo(int(math.Ceil(float64(capacity)*cfg.growthFactor))), capacity*2)
+}
+
+// shrinkOpenAddressingCapacity returns the number of slots an open-addressing table holding size
+// elements should have; if the load factor is still acceptable the current capacity is returned.
+func shrinkOpenAddressingCapacity(capacity, size int, cfg hashTableConfig) int {
+	if capacity <= defaultOpenAddressingCapacity {
+		return capacity
+	}
+
+	if float64(size) >= float64(capacity)*cfg.maxLoadFactor/4 {
+		return capacity
+	}
+
+	shrunk := nextPowerOfTwo(int(float64(capacity) / cfg.growthFactor))
+	if shrunk >= capacity {
+		shrunk = capacity / 2
+	}
+	shrunk = max(shrunk, nextPowerOfTwo(shrinkFloor(size, cfg)), defaultOpenAddressingCapacity)
+
+	return min(shrunk, capacity)
+}
+
+// nextPowerOfTwo returns the smallest power of two that is greater than or equal to n.
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
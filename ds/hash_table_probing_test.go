@@ -0,0 +1,215 @@
+package ds_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// runStringHashTableTests runs through a set of behaviours common to every StringHashTable
+// implementation.
+func runStringHashTableTests(t *testing.T, newTable func(...ds.HashTableOption) ds.StringHashTable) {
+	t.Run("Should create an empty table", func(t *testing.T) {
+		h := newTable()
+		assert.Equal(t, 0, h.Len())
+	})
+
+	t.Run("Should allow duplicate elements", func(t *testing.T) {
+		h := newTable()
+		h.Add("a test")
+		h.Add("a test")
+		h.Add("a test")
+		assert.Equal(t, 3, h.Len())
+
+		assert.Nil(t, h.Remove("a test"))
+		assert.Nil(t, h.Remove("a test"))
+		assert.Nil(t, h.Remove("a test"))
+		assert.NotNil(t, h.Remove("a test"))
+		assert.Equal(t, 0, h.Len())
+	})
+
+	t.Run("Remove from an empty table", func(t *testing.T) {
+		h := newTable()
+		assert.NotNil(t, h.Remove("missing"))
+		assert.Equal(t, 0, h.Len())
+	})
+
+	t.Run("Every element should remain reachable while growing and shrinking", func(t *testing.T) {
+		h := newTable()
+		for i := range 2000 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.Equal(t, 2000, h.Len())
+		grown := h.Cap()
+
+		// remove every other element first so that removals interleave with surviving elements
+		for i := 0; i < 2000; i += 2 {
+			assert.Nil(t, h.Remove(fmt.Sprintf("element-%d", i)))
+		}
+		for i := 1; i < 2000; i += 2 {
+			assert.Nil(t, h.Remove(fmt.Sprintf("element-%d", i)))
+		}
+		assert.Equal(t, 0, h.Len())
+		assert.Less(t, h.Cap(), grown)
+	})
+
+	t.Run("Should survive repeated add/remove churn", func(t *testing.T) {
+		h := newTable()
+		for i := range 5000 {
+			h.Add(fmt.Sprintf("element-%d", i))
+			if i >= 5 {
+				assert.Nil(t, h.Remove(fmt.Sprintf("element-%d", i-5)))
+			}
+		}
+		assert.Equal(t, 5, h.Len())
+		for i := 4995; i < 5000; i++ {
+			assert.Nil(t, h.Remove(fmt.Sprintf("element-%d", i)))
+		}
+	})
+
+	t.Run("Contains should report membership by value", func(t *testing.T) {
+		h := newTable()
+		assert.False(t, h.Contains("a test"))
+		h.Add("a test")
+		h.Add("another test")
+		assert.True(t, h.Contains("a test"))
+		assert.True(t, h.Contains("another test"))
+		assert.False(t, h.Contains("missing"))
+
+		assert.Nil(t, h.Remove("a test"))
+		assert.False(t, h.Contains("a test"))
+		assert.True(t, h.Contains("another test"))
+	})
+
+	t.Run("All should yield every occurrence in multiset mode", func(t *testing.T) {
+		h := newTable()
+		h.Add("b")
+		h.Add("a")
+		h.Add("b")
+		h.Add("c")
+
+		elems := slices.Sorted(h.All())
+		assert.Equal(t, []string{"a", "b", "b", "c"}, elems)
+	})
+
+	t.Run("All should stop when the consumer stops", func(t *testing.T) {
+		h := newTable()
+		for i := range 100 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+
+		count := 0
+		for range h.All() {
+			count++
+			if count == 10 {
+				break
+			}
+		}
+		assert.Equal(t, 10, count)
+	})
+
+	t.Run("Should ignore duplicate elements when duplicates are rejected", func(t *testing.T) {
+		h := newTable(ds.WithDuplicateMode(ds.DuplicatesRejected))
+		for range 3 {
+			h.Add("a test")
+			h.Add("another test")
+		}
+		assert.Equal(t, 2, h.Len())
+		assert.Equal(t, []string{"a test", "another test"}, slices.Sorted(h.All()))
+
+		assert.Nil(t, h.Remove("a test"))
+		assert.False(t, h.Contains("a test"))
+		assert.NotNil(t, h.Remove("a test"))
+	})
+
+	t.Run("Should work with every hasher", func(t *testing.T) {
+		hashers := []ds.Hasher{
+			ds.FNV1aHasher{},
+			ds.XXHash64Hasher{},
+			ds.NewRandomSipHasher(),
+			ds.Murmur3Hasher{},
+		}
+		for _, hasher := range hashers {
+			h := newTable(ds.WithHasher(hasher))
+			for i := range 500 {
+				h.Add(fmt.Sprintf("élément-%d", i))
+			}
+			for i := range 500 {
+				assert.Nil(t, h.Remove(fmt.Sprintf("élément-%d", i)))
+			}
+			assert.Equal(t, 0, h.Len())
+		}
+	})
+
+	t.Run("Should not thrash when shrinking with a large growth factor", func(t *testing.T) {
+		h := newTable(ds.WithMaxLoadFactor(0.5), ds.WithGrowthFactor(64))
+		for i := range 1000 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		for i := range 900 {
+			assert.Nil(t, h.Remove(fmt.Sprintf("element-%d", i)))
+			assert.LessOrEqual(t, float64(h.Len())/float64(h.Cap()), 0.5)
+		}
+
+		// alternating adds and removals around the same size should not resize the table
+		capacity := h.Cap()
+		for i := range 100 {
+			h.Add(fmt.Sprintf("churn-%d", i))
+			assert.Nil(t, h.Remove(fmt.Sprintf("churn-%d", i)))
+		}
+		assert.Equal(t, capacity, h.Cap())
+	})
+
+	t.Run("Should respect the configured max load factor", func(t *testing.T) {
+		h := newTable(ds.WithMaxLoadFactor(0.25))
+		for i := range 100 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.LessOrEqual(t, float64(h.Len())/float64(h.Cap()), 0.25)
+	})
+}
+
+func TestLinearProbingHashTable(t *testing.T) {
+	runStringHashTableTests(t, func(opts ...ds.HashTableOption) ds.StringHashTable {
+		return ds.NewLinearProbingHashTable(opts...)
+	})
+
+	t.Run("Capacity should always be a power of two", func(t *testing.T) {
+		h := ds.NewLinearProbingHashTable(ds.WithGrowthFactor(3))
+		for i := range 100 {
+			h.Add(fmt.Sprintf("element-%d", i))
+			assert.Zero(t, h.Cap()&(h.Cap()-1))
+		}
+	})
+
+	t.Run("Should clamp the max load factor so probing terminates", func(t *testing.T) {
+		h := ds.NewLinearProbingHashTable(ds.WithMaxLoadFactor(5))
+		for i := range 100 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.Less(t, h.Len(), h.Cap())
+	})
+}
+
+func TestQuadraticProbingHashTable(t *testing.T) {
+	runStringHashTableTests(t, func(opts ...ds.HashTableOption) ds.StringHashTable {
+		return ds.NewQuadraticProbingHashTable(opts...)
+	})
+
+	t.Run("Should clamp the max load factor so probing terminates", func(t *testing.T) {
+		h := ds.NewQuadraticProbingHashTable(ds.WithMaxLoadFactor(5))
+		for i := range 100 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.Less(t, h.Len(), h.Cap())
+	})
+}
+
+func TestHashTableImplementsStringHashTable(t *testing.T) {
+	runStringHashTableTests(t, func(opts ...ds.HashTableOption) ds.StringHashTable {
+		return ds.NewHashTable(opts...)
+	})
+}
@@ -0,0 +1,167 @@
+package ds
+
+import (
+	"errors"
+	"iter"
+)
+
+// robinHoodSlot is a single slot in a Robin Hood hash table. dist records how far the element sits
+// from its home slot.
+type robinHoodSlot struct {
+	elem     string
+	hash     uint
+	dist     int
+	occupied bool
+}
+
+// RobinHoodHashTable is an open-addressing hash table using linear probing where elements that are
+// far from their home slot may displace elements that are closer to theirs ('taking from the rich
+// and giving to the poor'), which keeps probe sequence lengths short and uniform. Removals use
+// backward-shift deletion so that no tombstones are needed.
+type RobinHoodHashTable struct {
+	slots  []robinHoodSlot
+	len    int
+	config hashTableConfig
+}
+
+// NewRobinHoodHashTable returns an empty Robin Hood hash table configured with the given options.
+func NewRobinHoodHashTable(opts ...HashTableOption) *RobinHoodHashTable {
+	cfg := newHashTableConfig(opts)
+	cfg.maxLoadFactor = min(cfg.maxLoadFactor, maxOpenAddressingLoadFactor)
+
+	return &RobinHoodHashTable{
+		slots:  make([]robinHoodSlot, defaultOpenAddressingCapacity),
+		len:    0,
+		config: cfg,
+	}
+}
+
+// Len returns the number of elements stored in the hash table
+func (ht RobinHoodHashTable) Len() int {
+	return ht.len
+}
+
+// Cap returns the number of slots in the hash table
+func (ht RobinHoodHashTable) Cap() int {
+	return len(ht.slots)
+}
+
+// Add adds an element to the hash table. If duplicates are rejected, adding an element that is
+// already in the table has no effect. The table will automatically resize if needed.
+func (ht *RobinHoodHashTable) Add(s string) {
+	if ht.config.mode == DuplicatesRejected && ht.Contains(s) {
+		return
+	}
+
+	if float64(ht.len+1) > float64(len(ht.slots))*ht.config.maxLoadFactor {
+		ht.resize(growOpenAddressingCapacity(len(ht.slots), ht.config))
+	}
+
+	ht.insert(s, ht.config.hasher.Hash(s))
+	ht.len++
+}
+
+// Remove removes a single occurrence of the element from the hash table; if the element is not found
+// an error is returned. The table will automatically shrink if needed.
+func (ht *RobinHoodHashTable) Remove(s string) error {
+	index, ok := ht.find(s, ht.config.hasher.Hash(s))
+	if !ok {
+		return errors.New("element not found")
+	}
+
+	// Backward-shift deletion: pull each following element one slot closer to its home slot until we
+	// reach an empty slot or an element that is already in its home slot
+	mask := len(ht.slots) - 1
+	next := (index + 1) & mask
+	for ht.slots[next].occupied && ht.slots[next].dist > 0 {
+		ht.slots[index] = ht.slots[next]
+		ht.slots[index].dist--
+		index = next
+		next = (next + 1) & mask
+	}
+	ht.slots[index] = robinHoodSlot{}
+	ht.len--
+
+	if capacity := shrinkOpenAddressingCapacity(len(ht.slots), ht.len, ht.config); capacity < len(ht.slots) {
+		ht.resize(capacity)
+	}
+
+	return nil
+}
+
+// Contains returns true if the element is in the hash table; else it returns false.
+func (ht RobinHoodHashTable) Contains(s string) bool {
+	_, ok := ht.find(s, ht.config.hasher.Hash(s))
+	return ok
+}
+
+// All returns an iterator over every element stored in the hash table, in no particular order. If
+// duplicates are allowed each occurrence of an element is yielded. The table must not be modified
+// during iteration.
+func (ht RobinHoodHashTable) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, slot := range ht.slots {
+			if slot.occupied && !yield(slot.elem) {
+				return
+			}
+		}
+	}
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// insert walks the probe sequence of the element, swapping it with any resident element that is
+// closer to its own home slot, until an empty slot is found.
+func (ht *RobinHoodHashTable) insert(s string, hash uint) {
+	mask := uint(len(ht.slots) - 1)
+	entry := robinHoodSlot{elem: s, hash: hash, dist: 0, occupied: true}
+	index := hash & mask
+
+	for {
+		slot := &ht.slots[index]
+		if !slot.occupied {
+			*slot = entry
+			return
+		}
+		if slot.dist < entry.dist {
+			// the resident is 'richer' than the element being placed; it gives up its slot
+			*slot, entry = entry, *slot
+		}
+		index = (index + 1) & mask
+		entry.dist++
+	}
+}
+
+// find returns the index of the slot holding the element. The search can stop early once it reaches
+// a resident that is closer to its home slot than the element would be, because insert would have
+// displaced that resident.
+func (ht RobinHoodHashTable) find(s string, hash uint) (int, bool) {
+	mask := uint(len(ht.slots) - 1)
+	index := hash & mask
+
+	for dist := 0; dist < len(ht.slots); dist++ {
+		slot := ht.slots[index]
+		if !slot.occupied || slot.dist < dist {
+			return 0, false
+		}
+		if slot.hash == hash && slot.elem == s {
+			return int(index), true
+		}
+		index = (index + 1) & mask
+	}
+
+	return 0, false
+}
+
+// resize allocates a table with the given number of slots and re-inserts every element.
+func (ht *RobinHoodHashTable) resize(capacity int) {
+	old := ht.slots
+	ht.slots = make([]robinHoodSlot, capacity)
+
+	for _, slot := range old {
+		if slot.occupied {
+			ht.insert(slot.elem, slot.hash)
+		}
+	}
+}
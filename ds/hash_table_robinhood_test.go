@@ -0,0 +1,29 @@
+package ds_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRobinHoodHashTable(t *testing.T) {
+	runStringHashTableTests(t, func(opts ...ds.HashTableOption) ds.StringHashTable {
+		return ds.NewRobinHoodHashTable(opts...)
+	})
+
+	t.Run("Should stay correct at a high load factor", func(t *testing.T) {
+		h := ds.NewRobinHoodHashTable(ds.WithMaxLoadFactor(0.9))
+		for i := range 1000 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.LessOrEqual(t, float64(h.Len())/float64(h.Cap()), 0.9)
+
+		for i := 999; i >= 0; i-- {
+			assert.Nil(t, h.Remove(fmt.Sprintf("element-%d", i)))
+			assert.NotNil(t, h.Remove(fmt.Sprintf("element-%d", i)))
+		}
+		assert.Equal(t, 0, h.Len())
+	})
+}
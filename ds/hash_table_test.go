@@ -1,6 +1,7 @@
 package ds_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/bcdxn/dsa-go/ds"
@@ -104,3 +105,137 @@ func TestHashTableGet(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestHashTableResize(t *testing.T) {
+	t.Run("Every element should remain reachable after growing", func(t *testing.T) {
+		h := ds.NewHashTable()
+		for i := range 1000 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.Equal(t, 1000, h.Len())
+
+		for i := range 1000 {
+			err := h.Remove(fmt.Sprintf("element-%d", i))
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, 0, h.Len())
+	})
+
+	t.Run("Should respect the configured max load factor and growth factor", func(t *testing.T) {
+		h := ds.NewHashTable(ds.WithMaxLoadFactor(1), ds.WithGrowthFactor(3))
+		for i := range 10 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.Equal(t, 10, h.Cap())
+
+		h.Add("one too many")
+		assert.Equal(t, 30, h.Cap())
+		assert.LessOrEqual(t, h.Stats().LoadFactor, 1.0)
+	})
+
+	t.Run("Should ignore invalid options", func(t *testing.T) {
+		h := ds.NewHashTable(ds.WithMaxLoadFactor(-1), ds.WithGrowthFactor(0.5))
+		for i := range 6 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		assert.Equal(t, 20, h.Cap())
+	})
+
+	t.Run("Should shrink as elements are removed", func(t *testing.T) {
+		h := ds.NewHashTable()
+		for i := range 1000 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		grown := h.Cap()
+
+		for i := range 990 {
+			err := h.Remove(fmt.Sprintf("element-%d", i))
+			assert.Nil(t, err)
+		}
+		assert.Less(t, h.Cap(), grown)
+		assert.GreaterOrEqual(t, h.Cap(), 10)
+		assert.LessOrEqual(t, h.Stats().LoadFactor, 0.5)
+	})
+
+	t.Run("Should not thrash when shrinking with a large growth factor", func(t *testing.T) {
+		h := ds.NewHashTable(ds.WithGrowthFactor(64))
+		for i := range 1000 {
+			h.Add(fmt.Sprintf("element-%d", i))
+		}
+		for i := range 900 {
+			err := h.Remove(fmt.Sprintf("element-%d", i))
+			assert.Nil(t, err)
+			assert.LessOrEqual(t, h.Stats().LoadFactor, 0.5)
+		}
+
+		// alternating adds and removals around the same size should not resize the table
+		capacity := h.Cap()
+		for i := range 100 {
+			h.Add(fmt.Sprintf("churn-%d", i))
+			err := h.Remove(fmt.Sprintf("churn-%d", i))
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, capacity, h.Cap())
+	})
+}
+
+func TestHashTableRemove(t *testing.T) {
+	t.Run("Remove from an empty table", func(t *testing.T) {
+		h := ds.NewHashTable()
+		err := h.Remove("missing")
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, h.Len())
+	})
+
+	t.Run("Remove should only remove a single occurrence", func(t *testing.T) {
+		h := ds.NewHashTable()
+		h.Add("a test")
+		h.Add("a test")
+
+		err := h.Remove("a test")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, h.Len())
+
+		s, err := h.Get(ds.Dbj2Hash("a test"))
+		assert.Nil(t, err)
+		assert.Equal(t, "a test", s)
+	})
+}
+
+func TestHashTableStats(t *testing.T) {
+	t.Run("Stats on an empty table", func(t *testing.T) {
+		h := ds.NewHashTable()
+		stats := h.Stats()
+		assert.Equal(t, 10, stats.Buckets)
+		assert.Equal(t, 0, stats.UsedBuckets)
+		assert.Equal(t, 0, stats.LongestChain)
+		assert.Equal(t, 0.0, stats.LoadFactor)
+	})
+
+	t.Run("Stats should report the longest chain", func(t *testing.T) {
+		h := ds.NewHashTable()
+		h.Add("a test")
+		h.Add("a test")
+		h.Add("a test")
+		h.Add("another")
+
+		stats := h.Stats()
+		assert.Equal(t, 3, stats.LongestChain)
+		assert.Equal(t, 2, stats.UsedBuckets)
+		assert.InDelta(t, 4.0/float64(stats.Buckets), stats.LoadFactor, 0.0001)
+	})
+}
+
+func TestHashTableWithHasher(t *testing.T) {
+	t.Run("Should place elements using the configured hasher", func(t *testing.T) {
+		hasher := ds.FNV1aHasher{}
+		h := ds.NewHashTable(ds.WithHasher(hasher))
+		h.Add("a test element")
+		h.Add("日本語の要素")
+
+		s, err := h.Get(hasher.Hash("日本語の要素"))
+		assert.Nil(t, err)
+		assert.Equal(t, "日本語の要素", s)
+		assert.True(t, h.Contains("a test element"))
+	})
+}
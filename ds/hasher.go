@@ -0,0 +1,310 @@
+package ds
+
+import (
+	"crypto/rand"
+	"math/bits"
+)
+
+// Hasher is implemented by hash functions that can be used by the hashed data structures in this
+// package.
+type Hasher interface {
+	// Hash returns the hash of the given input
+	Hash(input string) uint
+}
+
+// HasherFunc adapts an ordinary function, such as Dbj2Hash, to the Hasher interface.
+type HasherFunc func(input string) uint
+
+// Hash calls f(input).
+func (f HasherFunc) Hash(input string) uint {
+	return f(input)
+}
+
+// Dbj2Hasher is the Hasher used by default throughout this package.
+var Dbj2Hasher Hasher = HasherFunc(Dbj2Hash)
+
+/* FNV-1a
+------------------------------------------------------------------------------------------------- */
+
+const (
+	fnv64Offset = 14695981039346656037
+	fnv64Prime  = 1099511628211
+)
+
+// FNV1aHasher implements the 64-bit [Fowler–Noll–Vo (FNV-1a)][0] hash function. It is simple and
+// fast with good dispersion for short keys, but it is not resistant to deliberate collisions.
+//
+// [0]: https://en.wikipedia.org/wiki/Fowler%E2%80%93Noll%E2%80%93Vo_hash_function
+type FNV1aHasher struct{}
+
+// Hash returns the FNV-1a hash of the input.
+func (h FNV1aHasher) Hash(input string) uint {
+	return uint(h.Sum64(input))
+}
+
+// Sum64 returns the 64-bit FNV-1a hash of the input.
+func (FNV1aHasher) Sum64(input string) uint64 {
+	var hash uint64 = fnv64Offset
+
+	for i := 0; i < len(input); i++ {
+		hash ^= uint64(input[i])
+		hash *= fnv64Prime
+	}
+
+	return hash
+}
+
+/* xxHash64
+------------------------------------------------------------------------------------------------- */
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+// XXHash64Hasher implements the [xxHash64][0] hash function, which consumes its input 32 bytes at
+// a time and is considerably faster than FNV-1a on long keys. It is not resistant to deliberate
+// collisions.
+//
+// [0]: https://github.com/Cyan4973/xxHash/blob/dev/doc/xxhash_spec.md
+type XXHash64Hasher struct {
+	Seed uint64
+}
+
+// Hash returns the xxHash64 hash of the input.
+func (h XXHash64Hasher) Hash(input string) uint {
+	return uint(h.Sum64(input))
+}
+
+// Sum64 returns the 64-bit xxHash64 hash of the input.
+func (h XXHash64Hasher) Sum64(input string) uint64 {
+	n := len(input)
+	i := 0
+	var hash uint64
+
+	if n >= 32 {
+		v1 := h.Seed + xxPrime1 + xxPrime2
+		v2 := h.Seed + xxPrime2
+		v3 := h.Seed
+		v4 := h.Seed - xxPrime1
+		// consume the input in 32 byte stripes, one 8 byte lane per accumulator
+		for ; i+32 <= n; i += 32 {
+			v1 = xxRound(v1, readUint64(input, i))
+			v2 = xxRound(v2, readUint64(input, i+8))
+			v3 = xxRound(v3, readUint64(input, i+16))
+			v4 = xxRound(v4, readUint64(input, i+24))
+		}
+		hash = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) +
+			bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		hash = xxMergeRound(hash, v1)
+		hash = xxMergeRound(hash, v2)
+		hash = xxMergeRound(hash, v3)
+		hash = xxMergeRound(hash, v4)
+	} else {
+		hash = h.Seed + xxPrime5
+	}
+
+	hash += uint64(n)
+	// consume the remaining input 8, 4 and then 1 byte at a time
+	for ; i+8 <= n; i += 8 {
+		hash ^= xxRound(0, readUint64(input, i))
+		hash = bits.RotateLeft64(hash, 27)*xxPrime1 + xxPrime4
+	}
+	if i+4 <= n {
+		hash ^= uint64(readUint32(input, i)) * xxPrime1
+		hash = bits.RotateLeft64(hash, 23)*xxPrime2 + xxPrime3
+		i += 4
+	}
+	for ; i < n; i++ {
+		hash ^= uint64(input[i]) * xxPrime5
+		hash = bits.RotateLeft64(hash, 11) * xxPrime1
+	}
+	// final avalanche so that every input bit affects every output bit
+	hash ^= hash >> 33
+	hash *= xxPrime2
+	hash ^= hash >> 29
+	hash *= xxPrime3
+	hash ^= hash >> 32
+
+	return hash
+}
+
+func xxRound(acc, lane uint64) uint64 {
+	acc += lane * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	acc ^= xxRound(0, val)
+	return acc*xxPrime1 + xxPrime4
+}
+
+/* SipHash-2-4
+------------------------------------------------------------------------------------------------- */
+
+// SipHasher implements the keyed [SipHash-2-4][0] pseudorandom function. As long as the key is kept
+// secret, an attacker cannot construct inputs that collide, which protects hash tables holding
+// untrusted keys from hash flooding (denial of service) attacks.
+//
+// [0]: https://en.wikipedia.org/wiki/SipHash
+type SipHasher struct {
+	k0 uint64
+	k1 uint64
+}
+
+// NewSipHasher returns a SipHasher using the given 128-bit key.
+func NewSipHasher(key [16]byte) SipHasher {
+	s := string(key[:])
+	return SipHasher{
+		k0: readUint64(s, 0),
+		k1: readUint64(s, 8),
+	}
+}
+
+// NewRandomSipHasher returns a SipHasher using a key read from a cryptographically secure random
+// number generator.
+func NewRandomSipHasher() SipHasher {
+	var key [16]byte
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(key[:])
+	return NewSipHasher(key)
+}
+
+// Hash returns the SipHash-2-4 hash of the input.
+func (h SipHasher) Hash(input string) uint {
+	return uint(h.Sum64(input))
+}
+
+// Sum64 returns the 64-bit SipHash-2-4 hash of the input.
+func (h SipHasher) Sum64(input string) uint64 {
+	v0 := h.k0 ^ 0x736f6d6570736575
+	v1 := h.k1 ^ 0x646f72616e646f6d
+	v2 := h.k0 ^ 0x6c7967656e657261
+	v3 := h.k1 ^ 0x7465646279746573
+
+	n := len(input)
+	i := 0
+	// compress every full 8 byte word using 2 rounds
+	for ; i+8 <= n; i += 8 {
+		m := readUint64(input, i)
+		v3 ^= m
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+		v0 ^= m
+	}
+	// the final word holds the remaining bytes with the input length in the most significant byte
+	m := uint64(n) << 56
+	for j := n - 1; j >= i; j-- {
+		m |= uint64(input[j]) << (8 * (j - i))
+	}
+	v3 ^= m
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	v0 ^= m
+	// finalize using 4 rounds
+	v2 ^= 0xff
+	for range 4 {
+		v0, v1, v2, v3 = sipRound(v0, v1, v2, v3)
+	}
+
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+func sipRound(v0, v1, v2, v3 uint64) (uint64, uint64, uint64, uint64) {
+	v0 += v1
+	v1 = bits.RotateLeft64(v1, 13)
+	v1 ^= v0
+	v0 = bits.RotateLeft64(v0, 32)
+	v2 += v3
+	v3 = bits.RotateLeft64(v3, 16)
+	v3 ^= v2
+	v0 += v3
+	v3 = bits.RotateLeft64(v3, 21)
+	v3 ^= v0
+	v2 += v1
+	v1 = bits.RotateLeft64(v1, 17)
+	v1 ^= v2
+	v2 = bits.RotateLeft64(v2, 32)
+	return v0, v1, v2, v3
+}
+
+/* Murmur3
+------------------------------------------------------------------------------------------------- */
+
+const (
+	murmurC1 uint32 = 0xcc9e2d51
+	murmurC2 uint32 = 0x1b873593
+)
+
+// Murmur3Hasher implements the 32-bit [MurmurHash3][0] hash function (the x86_32 variant). It is
+// not resistant to deliberate collisions.
+//
+// [0]: https://en.wikipedia.org/wiki/MurmurHash
+type Murmur3Hasher struct {
+	Seed uint32
+}
+
+// Hash returns the Murmur3 hash of the input.
+func (h Murmur3Hasher) Hash(input string) uint {
+	return uint(h.Sum32(input))
+}
+
+// Sum32 returns the 32-bit Murmur3 hash of the input.
+func (h Murmur3Hasher) Sum32(input string) uint32 {
+	n := len(input)
+	hash := h.Seed
+	i := 0
+	// mix in the input 4 bytes at a time
+	for ; i+4 <= n; i += 4 {
+		hash ^= murmurMix(readUint32(input, i))
+		hash = bits.RotateLeft32(hash, 13)
+		hash = hash*5 + 0xe6546b64
+	}
+	// mix in the 1-3 remaining bytes
+	var k uint32
+	switch n - i {
+	case 3:
+		k ^= uint32(input[i+2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(input[i+1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(input[i])
+		hash ^= murmurMix(k)
+	}
+	// final avalanche so that every input bit affects every output bit
+	hash ^= uint32(n)
+	hash ^= hash >> 16
+	hash *= 0x85ebca6b
+	hash ^= hash >> 13
+	hash *= 0xc2b2ae35
+	hash ^= hash >> 16
+
+	return hash
+}
+
+func murmurMix(k uint32) uint32 {
+	k *= murmurC1
+	k = bits.RotateLeft32(k, 15)
+	return k * murmurC2
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// readUint64 reads 8 bytes of the input starting at index i as a little-endian integer.
+func readUint64(input string, i int) uint64 {
+	return uint64(input[i]) | uint64(input[i+1])<<8 | uint64(input[i+2])<<16 |
+		uint64(input[i+3])<<24 | uint64(input[i+4])<<32 | uint64(input[i+5])<<40 |
+		uint64(input[i+6])<<48 | uint64(input[i+7])<<56
+}
+
+// readUint32 reads 4 bytes of the input starting at index i as a little-endian integer.
+func readUint32(input string, i int) uint32 {
+	return uint32(input[i]) | uint32(input[i+1])<<8 | uint32(input[i+2])<<16 | uint32(input[i+3])<<24
+}
@@ -0,0 +1,166 @@
+package ds_test
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// sipKey is the key used by the reference SipHash-2-4 test vectors (bytes 0x00-0x0f).
+func sipKey() [16]byte {
+	var key [16]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	return key
+}
+
+// sipMessage returns the reference SipHash-2-4 test vector input of the given length (bytes
+// 0x00, 0x01, ...).
+func sipMessage(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return string(b)
+}
+
+// unicodeCorpus returns every two character string that can be made from the first 96 code points
+// of a handful of non-ASCII scripts.
+func unicodeCorpus() []string {
+	scripts := []rune{
+		0x0370,  // Greek
+		0x0400,  // Cyrillic
+		0x0900,  // Devanagari
+		0x4E00,  // CJK Unified Ideographs
+		0xAC00,  // Hangul Syllables
+		0x1F600, // Emoticons
+	}
+
+	var corpus []string
+	for _, start := range scripts {
+		for a := start; a < start+96; a++ {
+			for b := start; b < start+96; b++ {
+				corpus = append(corpus, string([]rune{a, b}))
+			}
+		}
+	}
+	return corpus
+}
+
+func TestDbj2HashUnicode(t *testing.T) {
+	t.Run("Should hash every byte of multi-byte characters", func(t *testing.T) {
+		// U+0100 and U+0200 share the same low byte
+		assert.NotEqual(t, ds.Dbj2Hash("Ā"), ds.Dbj2Hash("Ȁ"))
+		assert.NotEqual(t, ds.Dbj2Hash("日本"), ds.Dbj2Hash("本日"))
+	})
+}
+
+func TestFNV1aHasher(t *testing.T) {
+	t.Run("Should match the standard library implementation", func(t *testing.T) {
+		for _, s := range []string{"", "a", "foobar", "Hello, 世界", "😀"} {
+			expected := fnv.New64a()
+			expected.Write([]byte(s))
+			assert.Equal(t, expected.Sum64(), ds.FNV1aHasher{}.Sum64(s))
+		}
+	})
+}
+
+func TestXXHash64Hasher(t *testing.T) {
+	t.Run("Should match the reference test vectors", func(t *testing.T) {
+		h := ds.XXHash64Hasher{}
+		assert.Equal(t, uint64(0xef46db3751d8e999), h.Sum64(""))
+		assert.Equal(t, uint64(0xd24ec4f1a98c6e5b), h.Sum64("a"))
+		assert.Equal(t, uint64(0x44bc2cf5ad770999), h.Sum64("abc"))
+		// long enough to exercise the 32 byte stripe loop
+		assert.Equal(t, uint64(0xfbcea83c8a378bf1), h.Sum64("Nobody inspects the spammish repetition"))
+	})
+
+	t.Run("Should change with the seed", func(t *testing.T) {
+		assert.NotEqual(t, ds.XXHash64Hasher{Seed: 1}.Sum64("abc"), ds.XXHash64Hasher{}.Sum64("abc"))
+	})
+}
+
+func TestSipHasher(t *testing.T) {
+	t.Run("Should match the reference test vectors", func(t *testing.T) {
+		h := ds.NewSipHasher(sipKey())
+		assert.Equal(t, uint64(0x726fdb47dd0e0e31), h.Sum64(sipMessage(0)))
+		assert.Equal(t, uint64(0x74f839c593dc67fd), h.Sum64(sipMessage(1)))
+		assert.Equal(t, uint64(0x93f5f5799a932462), h.Sum64(sipMessage(8)))
+		assert.Equal(t, uint64(0xa129ca6149be45e5), h.Sum64(sipMessage(15)))
+	})
+
+	t.Run("Should depend on the key", func(t *testing.T) {
+		var other [16]byte
+		assert.NotEqual(t, ds.NewSipHasher(sipKey()).Sum64("abc"), ds.NewSipHasher(other).Sum64("abc"))
+		assert.NotEqual(t, ds.NewRandomSipHasher().Sum64("abc"), ds.NewRandomSipHasher().Sum64("abc"))
+	})
+}
+
+func TestMurmur3Hasher(t *testing.T) {
+	t.Run("Should match the reference test vectors", func(t *testing.T) {
+		assert.Equal(t, uint32(0), ds.Murmur3Hasher{}.Sum32(""))
+		assert.Equal(t, uint32(0x514e28b7), ds.Murmur3Hasher{Seed: 1}.Sum32(""))
+		assert.Equal(t, uint32(0x248bfa47), ds.Murmur3Hasher{}.Sum32("hello"))
+		assert.Equal(t, uint32(0x2e4ff723), ds.Murmur3Hasher{}.Sum32("The quick brown fox jumps over the lazy dog"))
+		assert.Equal(t, uint32(0x5a97808a), ds.Murmur3Hasher{Seed: 0x9747b28c}.Sum32("aaaa"))
+		assert.Equal(t, uint32(0x24884cba), ds.Murmur3Hasher{Seed: 0x9747b28c}.Sum32("Hello, world!"))
+	})
+}
+
+func TestHasherCollisionRates(t *testing.T) {
+	corpus := unicodeCorpus()
+	hashers := []struct {
+		name string
+		h    ds.Hasher
+		// maxCollisions is the number of full-width collisions tolerated over the corpus; 32-bit
+		// hashes are expected to see the occasional birthday collision
+		maxCollisions int
+	}{
+		{"FNV-1a", ds.FNV1aHasher{}, 0},
+		{"xxHash64", ds.XXHash64Hasher{}, 0},
+		{"SipHash-2-4", ds.NewSipHasher(sipKey()), 0},
+		{"Murmur3", ds.Murmur3Hasher{}, 5},
+	}
+
+	for _, hh := range hashers {
+		t.Run(hh.name+" should rarely collide on Unicode input", func(t *testing.T) {
+			seen := make(map[uint]struct{}, len(corpus))
+			collisions := 0
+			for _, s := range corpus {
+				hash := hh.h.Hash(s)
+				if _, ok := seen[hash]; ok {
+					collisions++
+				}
+				seen[hash] = struct{}{}
+			}
+			assert.LessOrEqual(t, collisions, hh.maxCollisions)
+		})
+
+		t.Run(hh.name+" should spread Unicode input evenly across buckets", func(t *testing.T) {
+			const buckets = 4096
+			counts := make([]int, buckets)
+			for _, s := range corpus {
+				counts[hh.h.Hash(s)%buckets]++
+			}
+			// Pearson's chi-squared statistic is close to the number of buckets for a uniform
+			// distribution
+			expected := float64(len(corpus)) / buckets
+			chiSquared := 0.0
+			for _, c := range counts {
+				d := float64(c) - expected
+				chiSquared += d * d / expected
+			}
+			assert.Less(t, chiSquared, 1.25*buckets)
+		})
+	}
+}
+
+func TestHasherFunc(t *testing.T) {
+	t.Run("Should adapt a plain function", func(t *testing.T) {
+		assert.Equal(t, ds.Dbj2Hash("Hello"), ds.Dbj2Hasher.Hash("Hello"))
+		assert.Equal(t, uint(5), ds.HasherFunc(func(s string) uint { return uint(len(s)) }).Hash("Hello"))
+	})
+}
@@ -2,161 +2,377 @@ package ds
 
 import (
 	"errors"
+	"slices"
 
 	"golang.org/x/exp/constraints"
 )
 
-// MaxHeap implements a max heap data structure, ensuring a complete tree that satisfies the max
-// heap property
-type MaxHeap[T constraints.Ordered] struct {
-	s         *[]T
-	lastIndex int
-	size      int
+// Heap implements a binary heap data structure ordered by a less function, ensuring a complete tree
+// in which no element is less than its parent. The element at the top of the heap is therefore
+// always a least element; a less function of a > b gives a max heap.
+type Heap[T any] struct {
+	// s holds the complete tree in level order starting at index 1, which keeps the parent/child
+	// index arithmetic simple; s[0] is unused
+	s    []T
+	less func(a, b T) bool
+	// moved, if set, is called whenever an element is placed at a new index so that wrappers (such as
+	// IndexedPriorityQueue) can track the position of their elements
+	moved func(elem T, i int)
+	// capacity is the capacity the heap starts with, which it never shrinks below
+	capacity int
+	// shrink reports whether the underlying slice is shrunk as elements are removed
+	shrink bool
+}
+
+const defaultHeapCapacity = 5
+
+// HeapOption configures a heap at construction time.
+type HeapOption func(*heapConfig)
+
+type heapConfig struct {
+	capacity int
+	shrink   bool
 }
 
-// NewMaxHeap returns a new empty heap
-func NewMaxHeap[T constraints.Ordered]() *MaxHeap[T] {
-	s := make([]T, 5)
-	return &MaxHeap[T]{
-		s:         &s,
-		lastIndex: 0,
-		size:      0,
+// WithCapacity sets the initial capacity of the heap's underlying slice, which saves growing the
+// slice when the number of elements is known ahead of time. The heap never shrinks below its
+// initial capacity. Values less than 1 are ignored.
+func WithCapacity(n int) HeapOption {
+	return func(c *heapConfig) {
+		if n > 0 {
+			c.capacity = n
+		}
+	}
+}
+
+// WithoutShrinking stops the heap from shrinking its underlying slice as elements are removed, so
+// that memory is held for the lifetime of the heap (or until Reset is called).
+func WithoutShrinking() HeapOption {
+	return func(c *heapConfig) {
+		c.shrink = false
+	}
+}
+
+// NewHeap returns a new empty heap ordered by the given less function, which reports whether a
+// belongs closer to the top of the heap than b, configured with the given options.
+//
+// The heap's underlying slice grows by append whenever it fills up, so small heaps double in
+// capacity while large ones grow by a smaller factor. Unless WithoutShrinking is given, the
+// capacity is halved whenever the heap falls to a quarter of its capacity, so that a heap that
+// briefly held many elements does not hold on to the memory once they are removed. The heap must
+// then double in size before it next grows, so a heap hovering around one size does not repeatedly
+// grow and shrink.
+func NewHeap[T any](less func(a, b T) bool, opts ...HeapOption) *Heap[T] {
+	cfg := heapConfig{
+		capacity: defaultHeapCapacity,
+		shrink:   true,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Heap[T]{
+		s:        make([]T, 1, cfg.capacity+1),
+		less:     less,
+		capacity: cfg.capacity,
+		shrink:   cfg.shrink,
 	}
 }
 
 // Size returns the number of elements currently in the heap
-func (h MaxHeap[T]) Size() int {
-	return h.size
+func (h Heap[T]) Size() int {
+	return len(h.s) - 1
 }
 
 // Peek returns the element at the top of the heap without removing it
-func (h MaxHeap[T]) Peek() (T, error) {
+func (h Heap[T]) Peek() (T, error) {
 	if h.Size() == 0 {
 		var empty T
 		return empty, errors.New("cannot peek an empty heap")
 	}
-	return (*h.s)[1], nil
+	return h.s[1], nil
 }
 
-// Push adds an element to the heap and maintains the max heap property
-func (h *MaxHeap[T]) Push(elem T) {
-	if h.Size() == (len(*h.s) - 1) {
-		// increase the size of the underlying slice and copy the values over to the new slice
-		s := make([]T, len(*h.s)*2)
-		copy(s, *h.s)
-		h.s = &s
-	}
-	// Add the element to the end of the underlying slice and increment size
-	h.lastIndex++
-	(*h.s)[h.lastIndex] = elem
-	h.size++
+// Push adds an element to the heap and maintains the heap property
+func (h *Heap[T]) Push(elem T) {
+	// Add the element to the end of the underlying slice, growing it if needed
+	h.s = append(h.s, elem)
+	h.place(h.Size())
 	// Ensure heap property (percolate up)
-	pi := parentIndex(h.lastIndex)
-	ci := h.lastIndex
-	for pi > 0 {
-		if (*h.s)[pi] < (*h.s)[ci] {
-			// nodes do not satisfy the heap property and must be swapped
-			(*h.s)[pi], (*h.s)[ci] = (*h.s)[ci], (*h.s)[pi]
-			ci = pi
-			pi = parentIndex(pi)
-		} else {
-			// nodes satisfy the heap property
-			break
-		}
-	}
+	h.percolateUp(h.Size())
 }
 
-// Pop removes the element at the top of the heap and maintains the max heap property
-func (h *MaxHeap[T]) Pop() (T, error) {
-	var empty T
+// Pop removes the element at the top of the heap and maintains the heap property
+func (h *Heap[T]) Pop() (T, error) {
 	if h.Size() < 1 {
-		return empty, errors.New("cannot pop an empty tree")
+		var empty T
+		return empty, errors.New("cannot pop an empty heap")
 	}
 	// Remove the element at the root of the heap
-	elem := (*h.s)[1]
-	h.size--
-	// move the last child to the root to maintain a complete tree
-	(*h.s)[1] = (*h.s)[h.lastIndex]
-	(*h.s)[h.lastIndex] = empty
-	h.lastIndex--
-	// Ensure heap property (percolate down)
-	currIndex := 1
-	for leftChildIndex(currIndex) <= h.lastIndex {
-		left := leftChildIndex(currIndex)
-		right := rightChildIndex(currIndex)
+	return h.removeAt(1), nil
+}
 
-		var maxChildIndex int
+// Replace removes the element at the top of the heap and adds the given element in its place,
+// returning the removed element. This is cheaper than a Pop followed by a Push since the heap
+// property is restored with a single percolation. The new element is added even if it belongs
+// above the removed one.
+func (h *Heap[T]) Replace(elem T) (T, error) {
+	if h.Size() < 1 {
+		var empty T
+		return empty, errors.New("cannot replace the top of an empty heap")
+	}
 
-		if right <= h.lastIndex && (*h.s)[right] > (*h.s)[left] {
-			maxChildIndex = right
-		} else if left <= h.lastIndex {
-			maxChildIndex = left
-		} else {
-			// the node has no children and therefore satisfies the heap property
-			break
-		}
+	top := h.s[1]
+	h.s[1] = elem
+	h.place(1)
+	h.percolateDown(1)
 
-		if (*h.s)[currIndex] < (*h.s)[maxChildIndex] {
-			// nodes do not satisfy the heap property and must be swapped
-			(*h.s)[currIndex], (*h.s)[maxChildIndex] = (*h.s)[maxChildIndex], (*h.s)[currIndex]
-			currIndex = maxChildIndex
-		} else {
-			// nodes satisfy the heap property
-			break
-		}
-	}
+	return top, nil
+}
+
+// Items returns a copy of the elements in the heap, in the order they are stored in (a level-order
+// traversal of the tree). The copy does not share memory with the heap.
+func (h Heap[T]) Items() []T {
+	return slices.Clone(h.s[1:])
+}
+
+// Clear removes every element from the heap, keeping the underlying slice for reuse.
+func (h *Heap[T]) Clear() {
+	clear(h.s[1:])
+	h.s = h.s[:1]
+}
+
+// Reset removes every element from the heap and releases the underlying slice, returning the heap to
+// its initial capacity.
+func (h *Heap[T]) Reset() {
+	h.s = make([]T, 1, h.capacity+1)
+}
 
-	return elem, nil
+// MaxHeap implements a max heap data structure, ensuring a complete tree that satisfies the max
+// heap property
+type MaxHeap[T constraints.Ordered] struct {
+	Heap[T]
+}
+
+// NewMaxHeap returns a new empty heap configured with the given options
+func NewMaxHeap[T constraints.Ordered](opts ...HeapOption) *MaxHeap[T] {
+	return &MaxHeap[T]{*NewHeap(orderedGreater[T], opts...)}
+}
+
+// MinHeap implements a min heap data structure, ensuring a complete tree that satisfies the min
+// heap property
+type MinHeap[T constraints.Ordered] struct {
+	Heap[T]
+}
+
+// NewMinHeap returns a new empty heap configured with the given options
+func NewMinHeap[T constraints.Ordered](opts ...HeapOption) *MinHeap[T] {
+	return &MinHeap[T]{*NewHeap(orderedLess[T], opts...)}
+}
+
+// Heapify converts a copy of the given list into a valid max heap configured with the given options
+// in O(n) time. The list itself is left untouched.
+func Heapify[T constraints.Ordered](list []T, opts ...HeapOption) *MaxHeap[T] {
+	h := NewMaxHeap[T](opts...)
+	h.heapify(list)
+	return h
 }
 
-// Heapify converts the given list into a valid heap in O(n) time.
-func Heapify[T constraints.Ordered](list []T) *MaxHeap[T] {
-	h := NewMaxHeap[T]()
+// HeapifyFunc converts a copy of the given list into a valid heap ordered by the given less function
+// and configured with the given options in O(n) time. The list itself is left untouched.
+func HeapifyFunc[T any](list []T, less func(a, b T) bool, opts ...HeapOption) *Heap[T] {
+	h := NewHeap(less, opts...)
 	h.heapify(list)
 	return h
 }
 
-// heapify is a helper function to heapify a given list (giving us access to the private internal
-// slice that the heap is implemented on)
-func (h *MaxHeap[T]) heapify(list []T) *MaxHeap[T] {
-	if len(list) < 1 {
-		return h
+// HeapifySlice rearranges the given list in place into a valid heap ordered by the given less
+// function in O(n) time, with the top of the heap at list[0]. Together with FixHeapSlice and
+// PopHeapSlice, it lets a heap be kept in a slice owned by the caller (as the in-place heap sorts
+// do) using the same algorithms as Heap.
+func HeapifySlice[T any](list []T, less func(a, b T) bool) {
+	buildNodes(list, less, nil)
+}
+
+// FixHeapSlice restores the heap property of a list arranged by HeapifySlice after the element at
+// list[i] has changed, in O(log n) time.
+func FixHeapSlice[T any](list []T, i int, less func(a, b T) bool) {
+	fixNode(list, i+1, less, nil)
+}
+
+// PopHeapSlice moves the top of a heap arranged by HeapifySlice to the end of the list and restores
+// the heap property over the rest of the list, in O(log n) time. The heap then occupies
+// list[:len(list)-1].
+func PopHeapSlice[T any](list []T, less func(a, b T) bool) {
+	last := len(list) - 1
+	if last < 1 {
+		return
 	}
-	h.size = len(list)
-	// Move 0th element in the list to the end to make pointer arthmetic simpler
-	list = append(list, list[0])
+	list[0], list[last] = list[last], list[0]
+	percolateNodeDown(list[:last], 1, less, nil)
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// heapify replaces the contents of the heap with a copy of the given list and restores the heap
+// property
+func (h *Heap[T]) heapify(list []T) {
+	h.s = make([]T, len(list)+1, max(len(list), h.capacity)+1)
+	copy(h.s[1:], list)
+	h.build()
+}
+
+// build restores the heap property over the whole of the underlying slice from the bottom up
+func (h *Heap[T]) build() {
+	buildNodes(h.s[1:], h.less, h.moved)
+}
+
+// removeAt removes and returns the element at the given index, moving the last element into its
+// place and restoring the heap property around it
+func (h *Heap[T]) removeAt(i int) T {
 	var empty T
-	list[0] = empty
-	h.s = &list
-	// We only need to 'percolate' elements with children; half the nodes
-	// in the heap will be leaf nodes
-	currIndex := len(*h.s) / 2
-	// Stop the loop when we've reached the second level of the heap (the root can't percolate up
-	// any more)
-	for currIndex > 0 {
-		percolateIndex := currIndex
-		for percolateIndex < len(*h.s)-1 {
-			li := leftChildIndex(percolateIndex)
-			ri := rightChildIndex(percolateIndex)
-			maxChildIndex := li
-			// Find the larger of the two children
-			if ri < len(*h.s) && (*h.s)[ri] > (*h.s)[li] {
-				maxChildIndex = ri
-			}
-			// swap with largest child (if largest child is greater than current node)
-			if maxChildIndex < len(*h.s) && (*h.s)[percolateIndex] < (*h.s)[maxChildIndex] {
-				(*h.s)[maxChildIndex], (*h.s)[percolateIndex] = (*h.s)[percolateIndex], (*h.s)[maxChildIndex]
-				percolateIndex = maxChildIndex
-			} else {
-				// the heap property is satisfied at the current node
-				break
-			}
+	elem := h.s[i]
+	// move the last child into the gap to maintain a complete tree
+	last := h.Size()
+	h.s[i] = h.s[last]
+	h.s[last] = empty
+	h.s = h.s[:last]
+	if i < last {
+		h.place(i)
+		h.fix(i)
+	}
+	h.shrinkToFit()
+
+	return elem
+}
+
+// shrinkToFit halves the capacity of the underlying slice once the heap falls to a quarter of it,
+// unless shrinking is disabled or the slice is already at the heap's initial capacity. Waiting until
+// a quarter (rather than half) full means a heap hovering around a power of two does not
+// repeatedly grow and shrink.
+func (h *Heap[T]) shrinkToFit() {
+	if !h.shrink || cap(h.s)-1 <= h.capacity || h.Size() > (cap(h.s)-1)/4 {
+		return
+	}
+	s := make([]T, len(h.s), max((cap(h.s)-1)/2, h.capacity)+1)
+	copy(s, h.s)
+	h.s = s
+}
+
+// fix restores the heap property after the element at the given index has changed; the element
+// may need to move either up or down the heap
+func (h *Heap[T]) fix(i int) {
+	fixNode(h.s[1:], i, h.less, h.moved)
+}
+
+// percolateUp swaps the element at the given index with its parent until the heap property is
+// satisfied
+func (h *Heap[T]) percolateUp(i int) {
+	percolateNodeUp(h.s[1:], i, h.less, h.moved)
+}
+
+// percolateDown swaps the element at the given index with its least child until the heap property
+// is satisfied
+func (h *Heap[T]) percolateDown(i int) {
+	percolateNodeDown(h.s[1:], i, h.less, h.moved)
+}
+
+// place reports the index of the element at the given index to the moved hook, if there is one
+func (h *Heap[T]) place(i int) {
+	if h.moved != nil {
+		h.moved(h.s[i], i)
+	}
+}
+
+// The functions below implement the heap algorithms over a slice of nodes, shared by Heap and the
+// exported slice helpers. Nodes are numbered from 1 (the root) to keep the parent/child index
+// arithmetic simple, so node i is stored at nodes[i-1]. moved, if not nil, is called with the new
+// number of every node that is moved.
+
+// buildNodes restores the heap property over every node from the bottom up
+func buildNodes[T any](nodes []T, less func(a, b T) bool, moved func(elem T, i int)) {
+	if moved != nil {
+		for i, elem := range nodes {
+			moved(elem, i+1)
 		}
-		// iterate 'up' the array to the root
-		currIndex--
 	}
+	// We only need to 'percolate' elements with children; half the nodes in the heap will be leaf
+	// nodes. Iterating 'up' the array to the root means each subtree is already a valid heap by the
+	// time its root is percolated.
+	for i := len(nodes) / 2; i > 0; i-- {
+		percolateNodeDown(nodes, i, less, moved)
+	}
+}
 
-	return h
+// fixNode restores the heap property after the given node has changed; the node may need to move
+// either up or down the heap
+func fixNode[T any](nodes []T, i int, less func(a, b T) bool, moved func(elem T, i int)) {
+	if i > 1 && less(nodes[i-1], nodes[parentIndex(i)-1]) {
+		percolateNodeUp(nodes, i, less, moved)
+	} else {
+		percolateNodeDown(nodes, i, less, moved)
+	}
+}
+
+// percolateNodeUp swaps the given node with its parent until the heap property is satisfied
+func percolateNodeUp[T any](nodes []T, ci int, less func(a, b T) bool, moved func(elem T, i int)) {
+	for pi := parentIndex(ci); pi > 0; pi = parentIndex(ci) {
+		if !less(nodes[ci-1], nodes[pi-1]) {
+			// nodes satisfy the heap property
+			break
+		}
+		// nodes do not satisfy the heap property and must be swapped
+		swapNodes(nodes, pi, ci, moved)
+		ci = pi
+	}
+}
+
+// percolateNodeDown swaps the given node with its least child until the heap property is satisfied
+func percolateNodeDown[T any](
+	nodes []T,
+	currIndex int,
+	less func(a, b T) bool,
+	moved func(elem T, i int),
+) {
+	last := len(nodes)
+	for leftChildIndex(currIndex) <= last {
+		left := leftChildIndex(currIndex)
+		right := rightChildIndex(currIndex)
+
+		// Find the lesser of the two children
+		minChildIndex := left
+		if right <= last && less(nodes[right-1], nodes[left-1]) {
+			minChildIndex = right
+		}
+
+		if !less(nodes[minChildIndex-1], nodes[currIndex-1]) {
+			// nodes satisfy the heap property
+			break
+		}
+		// nodes do not satisfy the heap property and must be swapped
+		swapNodes(nodes, currIndex, minChildIndex, moved)
+		currIndex = minChildIndex
+	}
+}
+
+// swapNodes exchanges the given nodes
+func swapNodes[T any](nodes []T, i, j int, moved func(elem T, i int)) {
+	nodes[i-1], nodes[j-1] = nodes[j-1], nodes[i-1]
+	if moved != nil {
+		moved(nodes[i-1], i)
+		moved(nodes[j-1], j)
+	}
+}
+
+// orderedLess orders elements from least to greatest
+func orderedLess[T constraints.Ordered](a, b T) bool {
+	return a < b
+}
+
+// orderedGreater orders elements from greatest to least
+func orderedGreater[T constraints.Ordered](a, b T) bool {
+	return a > b
 }
 
 // leftChildIndex returns in the index of the left child of the node at the given index
@@ -1,6 +1,8 @@
 package ds_test
 
 import (
+	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/bcdxn/dsa-go/ds"
@@ -130,3 +132,366 @@ func TestHeapify(t *testing.T) {
 		assert.Equal(t, 12, elem)
 	})
 }
+
+func TestHeapifyDrain(t *testing.T) {
+	t.Run("Heapify should pop every element in descending order", func(t *testing.T) {
+		list := []int{12, 1, 10, 5, 6, 3, 9, 11}
+
+		h := ds.Heapify(list)
+		assert.Equal(t, 8, h.Size())
+
+		var popped []int
+		for h.Size() > 0 {
+			elem, err := h.Pop()
+			assert.Nil(t, err)
+			popped = append(popped, elem)
+		}
+		assert.Equal(t, []int{12, 11, 10, 9, 6, 5, 3, 1}, popped)
+	})
+
+	t.Run("Heapify of an empty list should return an empty heap", func(t *testing.T) {
+		h := ds.Heapify([]int{})
+		assert.Equal(t, 0, h.Size())
+		h.Push(1)
+		p, _ := h.Peek()
+		assert.Equal(t, 1, p)
+	})
+}
+
+func TestNewMinHeap(t *testing.T) {
+	t.Run("Should pop elements in ascending order", func(t *testing.T) {
+		h := ds.NewMinHeap[int]()
+		for _, e := range []int{10, 5, 2, 100, 50, 2} {
+			h.Push(e)
+		}
+
+		var popped []int
+		for h.Size() > 0 {
+			elem, _ := h.Pop()
+			popped = append(popped, elem)
+		}
+		assert.Equal(t, []int{2, 2, 5, 10, 50, 100}, popped)
+	})
+}
+
+// task is a struct with no natural ordering, used to exercise heaps built from a less function.
+type task struct {
+	name     string
+	deadline int
+}
+
+func byDeadline(a, b task) bool {
+	return a.deadline < b.deadline
+}
+
+func TestNewHeap(t *testing.T) {
+	t.Run("Should order structs by the less function", func(t *testing.T) {
+		h := ds.NewHeap(byDeadline)
+		h.Push(task{"report", 30})
+		h.Push(task{"email", 5})
+		h.Push(task{"deploy", 12})
+
+		p, err := h.Peek()
+		assert.Nil(t, err)
+		assert.Equal(t, "email", p.name)
+
+		var names []string
+		for h.Size() > 0 {
+			next, _ := h.Pop()
+			names = append(names, next.name)
+		}
+		assert.Equal(t, []string{"email", "deploy", "report"}, names)
+	})
+
+	t.Run("Should pop elements in order for random input", func(t *testing.T) {
+		r := rand.New(rand.NewSource(18))
+		h := ds.NewHeap(func(a, b int) bool { return a < b })
+		var want []int
+		for range 1000 {
+			e := r.Intn(500)
+			h.Push(e)
+			want = append(want, e)
+		}
+		slices.Sort(want)
+
+		for _, e := range want {
+			got, err := h.Pop()
+			assert.Nil(t, err)
+			assert.Equal(t, e, got)
+		}
+		_, err := h.Pop()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestHeapifyFunc(t *testing.T) {
+	t.Run("Should build a valid heap from structs", func(t *testing.T) {
+		tasks := []task{{"a", 9}, {"b", 3}, {"c", 7}, {"d", 1}, {"e", 8}}
+		h := ds.HeapifyFunc(tasks, byDeadline)
+		assert.Equal(t, 5, h.Size())
+
+		var deadlines []int
+		for h.Size() > 0 {
+			next, _ := h.Pop()
+			deadlines = append(deadlines, next.deadline)
+		}
+		assert.Equal(t, []int{1, 3, 7, 8, 9}, deadlines)
+	})
+}
+
+func TestHeapSlice(t *testing.T) {
+	t.Run("Should pop every element of a heapified slice in order", func(t *testing.T) {
+		r := rand.New(rand.NewSource(20))
+		list := r.Perm(100)
+		ds.HeapifySlice(list, byValue)
+		assert.Equal(t, 0, list[0])
+
+		// each pop moves the minimum to the end of the shrinking heap, leaving the list descending
+		for end := len(list); end > 0; end-- {
+			ds.PopHeapSlice(list[:end], byValue)
+		}
+		assert.True(t, slices.IsSortedFunc(list, func(a, b int) int { return b - a }))
+	})
+
+	t.Run("Should restore the heap after an element changes", func(t *testing.T) {
+		list := []int{5, 3, 8, 1, 9, 2}
+		ds.HeapifySlice(list, byValue)
+
+		list[0] = 10
+		ds.FixHeapSlice(list, 0, byValue)
+		assert.Equal(t, 2, list[0])
+
+		list[len(list)-1] = 0
+		ds.FixHeapSlice(list, len(list)-1, byValue)
+		assert.Equal(t, 0, list[0])
+	})
+
+	t.Run("Should leave empty and single element slices alone", func(t *testing.T) {
+		empty := []int{}
+		ds.HeapifySlice(empty, byValue)
+		ds.PopHeapSlice(empty, byValue)
+		assert.Equal(t, []int{}, empty)
+
+		single := []int{7}
+		ds.HeapifySlice(single, byValue)
+		ds.PopHeapSlice(single, byValue)
+		assert.Equal(t, []int{7}, single)
+	})
+}
+
+// byValue orders ints from least to greatest.
+func byValue(a, b int) bool {
+	return a < b
+}
+
+func TestHeapReplace(t *testing.T) {
+	t.Run("Replace on an empty heap", func(t *testing.T) {
+		h := ds.NewMinHeap[int]()
+		_, err := h.Replace(1)
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, h.Size())
+	})
+
+	t.Run("Replace should return the top and keep the heap property", func(t *testing.T) {
+		h := ds.NewMinHeap[int]()
+		for _, e := range []int{4, 2, 8, 6} {
+			h.Push(e)
+		}
+
+		top, err := h.Replace(7)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, top)
+		assert.Equal(t, 4, h.Size())
+
+		var popped []int
+		for h.Size() > 0 {
+			elem, _ := h.Pop()
+			popped = append(popped, elem)
+		}
+		assert.Equal(t, []int{4, 6, 7, 8}, popped)
+	})
+}
+
+func TestHeapCapacity(t *testing.T) {
+	t.Run("Should start with the capacity hint", func(t *testing.T) {
+		h := ds.NewMaxHeap[int](ds.WithCapacity(100))
+		assert.Equal(t, 100, ds.HeapCapacity(&h.Heap))
+
+		for i := range 100 {
+			h.Push(i)
+		}
+		assert.Equal(t, 100, ds.HeapCapacity(&h.Heap), "Should not grow before it is full")
+	})
+
+	t.Run("Should ignore an invalid capacity hint", func(t *testing.T) {
+		h := ds.NewMinHeap[int](ds.WithCapacity(-1))
+		assert.Equal(t, 5, ds.HeapCapacity(&h.Heap))
+	})
+
+	t.Run("Should shrink back to the capacity hint as elements are popped", func(t *testing.T) {
+		h := ds.NewMaxHeap[int](ds.WithCapacity(16))
+		for i := range 10_000 {
+			h.Push(i)
+		}
+		grown := ds.HeapCapacity(&h.Heap)
+		assert.GreaterOrEqual(t, grown, 10_000)
+
+		for h.Size() > 1000 {
+			h.Pop()
+		}
+		assert.Less(t, ds.HeapCapacity(&h.Heap), grown)
+		assert.LessOrEqual(t, ds.HeapCapacity(&h.Heap), 4*1000)
+
+		for want := 999; h.Size() > 0; want-- {
+			elem, _ := h.Pop()
+			assert.Equal(t, want, elem)
+		}
+		assert.Equal(t, 16, ds.HeapCapacity(&h.Heap))
+	})
+
+	t.Run("Should not shrink when shrinking is disabled", func(t *testing.T) {
+		h := ds.NewMaxHeap[int](ds.WithoutShrinking())
+		for i := range 1000 {
+			h.Push(i)
+		}
+		grown := ds.HeapCapacity(&h.Heap)
+		for h.Size() > 0 {
+			h.Pop()
+		}
+		assert.Equal(t, grown, ds.HeapCapacity(&h.Heap))
+	})
+
+	t.Run("Should not allocate when pushing and popping at a steady size", func(t *testing.T) {
+		h := ds.NewMinHeap[int]()
+		for i := range 1000 {
+			h.Push(i)
+		}
+		allocs := testing.AllocsPerRun(100, func() {
+			for i := range 1000 {
+				h.Push(i)
+				h.Pop()
+			}
+		})
+		assert.Zero(t, allocs)
+	})
+}
+
+func TestHeapClear(t *testing.T) {
+	t.Run("Should empty the heap and keep its capacity", func(t *testing.T) {
+		h := ds.NewMaxHeap[int]()
+		for i := range 100 {
+			h.Push(i)
+		}
+		grown := ds.HeapCapacity(&h.Heap)
+
+		h.Clear()
+		assert.Equal(t, 0, h.Size())
+		_, err := h.Peek()
+		assert.NotNil(t, err)
+		assert.Equal(t, grown, ds.HeapCapacity(&h.Heap))
+
+		h.Push(3)
+		h.Push(7)
+		p, _ := h.Peek()
+		assert.Equal(t, 7, p)
+	})
+}
+
+func TestHeapReset(t *testing.T) {
+	t.Run("Should empty the heap and release its memory", func(t *testing.T) {
+		h := ds.NewMaxHeap[int](ds.WithCapacity(8), ds.WithoutShrinking())
+		for i := range 100 {
+			h.Push(i)
+		}
+
+		h.Reset()
+		assert.Equal(t, 0, h.Size())
+		assert.Equal(t, 8, ds.HeapCapacity(&h.Heap))
+
+		h.Push(3)
+		p, _ := h.Peek()
+		assert.Equal(t, 3, p)
+	})
+}
+
+func TestHeapItems(t *testing.T) {
+	t.Run("Should return a snapshot of the elements", func(t *testing.T) {
+		h := ds.Heapify([]int{3, 9, 1, 7, 5})
+		items := h.Items()
+		assert.Len(t, items, 5)
+		assert.Equal(t, 9, items[0], "Should be in heap order")
+		assert.ElementsMatch(t, []int{1, 3, 5, 7, 9}, items)
+
+		items[0] = 100
+		p, _ := h.Peek()
+		assert.Equal(t, 9, p, "Should not share memory with the heap")
+		h.Pop()
+		assert.Len(t, items, 5)
+	})
+
+	t.Run("Should return an empty snapshot of an empty heap", func(t *testing.T) {
+		assert.Empty(t, ds.NewMinHeap[int]().Items())
+	})
+}
+
+func TestHeapifyCopies(t *testing.T) {
+	t.Run("Pushing should never write to the given list", func(t *testing.T) {
+		backing := []int{3, 9, 1, 7, 5, 0, 0, 0}
+		list := backing[:5]
+		h := ds.Heapify(list, ds.WithCapacity(16))
+		h.Push(4)
+		h.Push(8)
+
+		assert.Equal(t, []int{3, 9, 1, 7, 5, 0, 0, 0}, backing, "Should not have side effects")
+		assert.Equal(t, 16, ds.HeapCapacity(&h.Heap))
+	})
+}
+
+/* Benchmarks
+------------------------------------------------------------------------------------------------- */
+
+// BenchmarkHeapSteadyState pushes and pops at a constant size, which should not allocate.
+func BenchmarkHeapSteadyState(b *testing.B) {
+	h := ds.NewMinHeap[int]()
+	for i := range 10_000 {
+		h.Push(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		h.Push(i)
+		h.Pop()
+	}
+}
+
+// BenchmarkHeapBurst fills the heap with a burst of elements and drains it again, reporting the
+// capacity the heap is left holding once it is empty.
+func BenchmarkHeapBurst(b *testing.B) {
+	for _, bm := range []struct {
+		name  string
+		opts  []ds.HeapOption
+		clear bool
+	}{
+		{"Shrinking", nil, false},
+		{"WithoutShrinking", []ds.HeapOption{ds.WithoutShrinking()}, false},
+		{"Clear", []ds.HeapOption{ds.WithoutShrinking()}, true},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			h := ds.NewMinHeap[int](bm.opts...)
+			b.ReportAllocs()
+			for range b.N {
+				for i := range 10_000 {
+					h.Push(i)
+				}
+				if bm.clear {
+					h.Clear()
+					continue
+				}
+				for h.Size() > 0 {
+					h.Pop()
+				}
+			}
+			b.ReportMetric(float64(ds.HeapCapacity(&h.Heap)), "cap-after-drain")
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package ds
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+// PQHandle identifies an element pushed onto an IndexedPriorityQueue. It is used to change the
+// element's priority or remove it from the queue after it has been pushed.
+type PQHandle[T any, P any] struct {
+	value    T
+	priority P
+	// index is the element's position in the heap, or 0 once it has left the queue
+	index int
+	// heap identifies the queue the element was pushed onto
+	heap *Heap[*PQHandle[T, P]]
+}
+
+// Value returns the element the handle refers to.
+func (h PQHandle[T, P]) Value() T {
+	return h.value
+}
+
+// Priority returns the element's current priority.
+func (h PQHandle[T, P]) Priority() P {
+	return h.priority
+}
+
+// IndexedPriorityQueue is a priority queue whose elements can have their priority changed, or be
+// removed, while they are in the queue. It is built on a Heap of handles that keeps each handle
+// up to date with its element's position in the heap, so that the element can be found and
+// re-percolated in O(log n) time.
+type IndexedPriorityQueue[T any, P any] struct {
+	heap *Heap[*PQHandle[T, P]]
+}
+
+// NewIndexedPriorityQueue returns a new empty queue ordered by the given less function, which
+// reports whether priority a belongs closer to the front of the queue than priority b.
+func NewIndexedPriorityQueue[T any, P any](less func(a, b P) bool) *IndexedPriorityQueue[T, P] {
+	heap := NewHeap(func(a, b *PQHandle[T, P]) bool {
+		return less(a.priority, b.priority)
+	})
+	heap.moved = func(h *PQHandle[T, P], i int) {
+		h.index = i
+	}
+
+	return &IndexedPriorityQueue[T, P]{heap: heap}
+}
+
+// NewIndexedMinPriorityQueue returns a new empty queue that serves the element with the smallest
+// priority first.
+func NewIndexedMinPriorityQueue[T any, P constraints.Ordered]() *IndexedPriorityQueue[T, P] {
+	return NewIndexedPriorityQueue[T](orderedLess[P])
+}
+
+// NewIndexedMaxPriorityQueue returns a new empty queue that serves the element with the largest
+// priority first.
+func NewIndexedMaxPriorityQueue[T any, P constraints.Ordered]() *IndexedPriorityQueue[T, P] {
+	return NewIndexedPriorityQueue[T](orderedGreater[P])
+}
+
+// Size returns the number of elements currently in the queue
+func (q IndexedPriorityQueue[T, P]) Size() int {
+	return q.heap.Size()
+}
+
+// Push adds an element to the queue with the given priority and returns a handle to it
+func (q *IndexedPriorityQueue[T, P]) Push(value T, priority P) *PQHandle[T, P] {
+	h := &PQHandle[T, P]{
+		value:    value,
+		priority: priority,
+		heap:     q.heap,
+	}
+	q.heap.Push(h)
+
+	return h
+}
+
+// Peek returns the element at the front of the queue and its priority without removing it
+func (q IndexedPriorityQueue[T, P]) Peek() (T, P, error) {
+	h, err := q.heap.Peek()
+	if err != nil {
+		var value T
+		var priority P
+		return value, priority, errors.New("cannot peek an empty queue")
+	}
+
+	return h.value, h.priority, nil
+}
+
+// Pop removes the element at the front of the queue and returns it along with its priority
+func (q *IndexedPriorityQueue[T, P]) Pop() (T, P, error) {
+	h, err := q.heap.Pop()
+	if err != nil {
+		var value T
+		var priority P
+		return value, priority, errors.New("cannot pop an empty queue")
+	}
+	h.index = 0
+
+	return h.value, h.priority, nil
+}
+
+// Contains returns true if the handle's element is still in the queue; else it returns false
+func (q IndexedPriorityQueue[T, P]) Contains(h *PQHandle[T, P]) bool {
+	return h != nil && h.heap == q.heap && h.index > 0
+}
+
+// Update changes the priority of the handle's element and moves it to its new position in the
+// queue in O(log n) time. Raising an element's priority (DecreaseKey in a min queue) and lowering
+// it are both supported. If the element is no longer in the queue an error is returned.
+func (q *IndexedPriorityQueue[T, P]) Update(h *PQHandle[T, P], priority P) error {
+	if !q.Contains(h) {
+		return errors.New("element is not in the queue")
+	}
+
+	h.priority = priority
+	q.heap.fix(h.index)
+
+	return nil
+}
+
+// Remove removes the handle's element from the queue in O(log n) time. If the element is no longer
+// in the queue an error is returned.
+func (q *IndexedPriorityQueue[T, P]) Remove(h *PQHandle[T, P]) error {
+	if !q.Contains(h) {
+		return errors.New("element is not in the queue")
+	}
+
+	q.heap.removeAt(h.index)
+	h.index = 0
+
+	return nil
+}
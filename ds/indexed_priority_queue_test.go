@@ -0,0 +1,193 @@
+package ds_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIndexedPriorityQueue(t *testing.T) {
+	t.Run("Should create an empty queue", func(t *testing.T) {
+		q := ds.NewIndexedMinPriorityQueue[string, int]()
+		assert.Equal(t, 0, q.Size())
+		_, _, err := q.Peek()
+		assert.NotNil(t, err)
+		_, _, err = q.Pop()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestIndexedPriorityQueuePush(t *testing.T) {
+	t.Run("Should serve elements in priority order", func(t *testing.T) {
+		q := ds.NewIndexedMinPriorityQueue[string, int]()
+		q.Push("c", 3)
+		q.Push("a", 1)
+		q.Push("b", 2)
+		assert.Equal(t, 3, q.Size())
+
+		v, p, err := q.Peek()
+		assert.Nil(t, err)
+		assert.Equal(t, "a", v)
+		assert.Equal(t, 1, p)
+
+		for _, want := range []string{"a", "b", "c"} {
+			v, _, err := q.Pop()
+			assert.Nil(t, err)
+			assert.Equal(t, want, v)
+		}
+	})
+
+	t.Run("Should serve the largest priority first in a max queue", func(t *testing.T) {
+		q := ds.NewIndexedMaxPriorityQueue[string, int]()
+		q.Push("low", 1)
+		q.Push("high", 10)
+		v, p, _ := q.Pop()
+		assert.Equal(t, "high", v)
+		assert.Equal(t, 10, p)
+	})
+}
+
+func TestIndexedPriorityQueueUpdate(t *testing.T) {
+	t.Run("Should move an element forward when its priority decreases", func(t *testing.T) {
+		q := ds.NewIndexedMinPriorityQueue[string, int]()
+		q.Push("a", 5)
+		q.Push("b", 6)
+		h := q.Push("c", 7)
+
+		assert.Nil(t, q.Update(h, 1))
+		assert.Equal(t, 1, h.Priority())
+		v, _, _ := q.Peek()
+		assert.Equal(t, "c", v)
+	})
+
+	t.Run("Should move an element back when its priority increases", func(t *testing.T) {
+		q := ds.NewIndexedMinPriorityQueue[string, int]()
+		h := q.Push("a", 1)
+		q.Push("b", 2)
+		q.Push("c", 3)
+
+		assert.Nil(t, q.Update(h, 10))
+		for _, want := range []string{"b", "c", "a"} {
+			v, _, _ := q.Pop()
+			assert.Equal(t, want, v)
+		}
+	})
+
+	t.Run("Should return an error for an element that has left the queue", func(t *testing.T) {
+		q := ds.NewIndexedMinPriorityQueue[string, int]()
+		h := q.Push("a", 1)
+		q.Pop()
+		assert.False(t, q.Contains(h))
+		assert.NotNil(t, q.Update(h, 0))
+	})
+}
+
+func TestIndexedPriorityQueueRemove(t *testing.T) {
+	t.Run("Should remove an element from the middle of the queue", func(t *testing.T) {
+		q := ds.NewIndexedMinPriorityQueue[string, int]()
+		q.Push("a", 1)
+		h := q.Push("b", 2)
+		q.Push("c", 3)
+
+		assert.True(t, q.Contains(h))
+		assert.Nil(t, q.Remove(h))
+		assert.False(t, q.Contains(h))
+		assert.Equal(t, 2, q.Size())
+		assert.NotNil(t, q.Remove(h))
+
+		for _, want := range []string{"a", "c"} {
+			v, _, _ := q.Pop()
+			assert.Equal(t, want, v)
+		}
+	})
+
+	t.Run("Should reject handles from another queue", func(t *testing.T) {
+		q1 := ds.NewIndexedMinPriorityQueue[string, int]()
+		q2 := ds.NewIndexedMinPriorityQueue[string, int]()
+		h := q1.Push("a", 1)
+		q2.Push("a", 1)
+
+		assert.False(t, q2.Contains(h))
+		assert.NotNil(t, q2.Remove(h))
+		assert.NotNil(t, q2.Update(h, 5))
+		assert.Equal(t, 1, q2.Size())
+		assert.False(t, q2.Contains(nil))
+	})
+
+	t.Run("Should match a map over random operations", func(t *testing.T) {
+		r := rand.New(rand.NewSource(19))
+		q := ds.NewIndexedMinPriorityQueue[int, int]()
+		live := map[int]*ds.PQHandle[int, int]{}
+
+		for i := range 5000 {
+			switch r.Intn(4) {
+			case 0:
+				live[i] = q.Push(i, r.Intn(1000))
+			case 1:
+				for _, h := range live {
+					assert.Nil(t, q.Update(h, r.Intn(1000)))
+					break
+				}
+			case 2:
+				for id, h := range live {
+					assert.Nil(t, q.Remove(h))
+					delete(live, id)
+					break
+				}
+			case 3:
+				if len(live) == 0 {
+					continue
+				}
+				min := -1
+				for _, h := range live {
+					if min == -1 || h.Priority() < min {
+						min = h.Priority()
+					}
+				}
+				v, p, err := q.Pop()
+				assert.Nil(t, err)
+				assert.Equal(t, min, p)
+				delete(live, v)
+			}
+			assert.Equal(t, len(live), q.Size())
+		}
+	})
+}
+
+func TestIndexedPriorityQueueDijkstra(t *testing.T) {
+	t.Run("Should find shortest paths using DecreaseKey", func(t *testing.T) {
+		edges := map[string]map[string]int{
+			"a": {"b": 7, "c": 9, "f": 14},
+			"b": {"a": 7, "c": 10, "d": 15},
+			"c": {"a": 9, "b": 10, "d": 11, "f": 2},
+			"d": {"b": 15, "c": 11, "e": 6},
+			"e": {"d": 6, "f": 9},
+			"f": {"a": 14, "c": 2, "e": 9},
+		}
+
+		q := ds.NewIndexedMinPriorityQueue[string, int]()
+		handles := map[string]*ds.PQHandle[string, int]{}
+		for v := range edges {
+			dist := 1 << 30
+			if v == "a" {
+				dist = 0
+			}
+			handles[v] = q.Push(v, dist)
+		}
+
+		dist := map[string]int{}
+		for q.Size() > 0 {
+			v, d, _ := q.Pop()
+			dist[v] = d
+			for w, weight := range edges[v] {
+				if h := handles[w]; q.Contains(h) && d+weight < h.Priority() {
+					assert.Nil(t, q.Update(h, d+weight))
+				}
+			}
+		}
+
+		assert.Equal(t, map[string]int{"a": 0, "b": 7, "c": 9, "d": 20, "e": 20, "f": 11}, dist)
+	})
+}
@@ -1,19 +1,15 @@
 package ds
 
-import (
-	"errors"
-
-	"golang.org/x/exp/constraints"
-)
+import "errors"
 
 // ListNode is an implementation of the building block of a Doubly Linked List.
-type ListNode[T constraints.Ordered] struct {
+type ListNode[T comparable] struct {
 	Elem T
 	Prev *ListNode[T]
 	Next *ListNode[T]
 }
 
-func newListNode[T constraints.Ordered](elem T) *ListNode[T] {
+func newListNode[T comparable](elem T) *ListNode[T] {
 	return &ListNode[T]{
 		Elem: elem,
 		Prev: nil,
@@ -22,14 +18,14 @@ func newListNode[T constraints.Ordered](elem T) *ListNode[T] {
 }
 
 // List is an implementation of the Doubly Linked List data structure.
-type List[T constraints.Ordered] struct {
+type List[T comparable] struct {
 	Head *ListNode[T]
 	Tail *ListNode[T]
 	len  int
 }
 
 // NewList creates and returns an empty doubly linked list.
-func NewList[T constraints.Ordered]() *List[T] {
+func NewList[T comparable]() *List[T] {
 	return &List[T]{
 		Head: nil,
 		Tail: nil,
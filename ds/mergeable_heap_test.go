@@ -0,0 +1,421 @@
+package ds_test
+
+import (
+	"cmp"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// every heap in the package is usable through the PriorityQueue interface
+var (
+	_ ds.PriorityQueue[int] = (*ds.Heap[int])(nil)
+	_ ds.PriorityQueue[int] = (*ds.MaxHeap[int])(nil)
+	_ ds.PriorityQueue[int] = (*ds.MinHeap[int])(nil)
+	_ ds.PriorityQueue[int] = (*ds.PairingHeap[int])(nil)
+	_ ds.PriorityQueue[int] = (*ds.BinomialHeap[int])(nil)
+	_ ds.PriorityQueue[int] = (*ds.FibonacciHeap[int])(nil)
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+// runPriorityQueueTests runs through a set of behaviours common to every PriorityQueue
+// implementation; less must describe the order the queue serves its elements in.
+func runPriorityQueueTests(t *testing.T, newQueue func() ds.PriorityQueue[int], less func(a, b int) bool) {
+	t.Run("Should create an empty queue", func(t *testing.T) {
+		q := newQueue()
+		assert.Equal(t, 0, q.Size())
+		_, err := q.Peek()
+		assert.NotNil(t, err)
+		_, err = q.Pop()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Should serve elements in order over random operations", func(t *testing.T) {
+		r := rand.New(rand.NewSource(21))
+		q := newQueue()
+		var oracle []int
+		order := func(a, b int) int {
+			if less(a, b) {
+				return -1
+			}
+			if less(b, a) {
+				return 1
+			}
+			return 0
+		}
+
+		for range 5000 {
+			if r.Intn(3) > 0 || len(oracle) == 0 {
+				elem := r.Intn(1000)
+				q.Push(elem)
+				oracle = append(oracle, elem)
+				slices.SortFunc(oracle, order)
+			} else {
+				p, err := q.Peek()
+				assert.Nil(t, err)
+				assert.Equal(t, oracle[0], p)
+				p, err = q.Pop()
+				assert.Nil(t, err)
+				assert.Equal(t, oracle[0], p)
+				oracle = oracle[1:]
+			}
+			assert.Equal(t, len(oracle), q.Size())
+		}
+
+		for _, want := range oracle {
+			p, err := q.Pop()
+			assert.Nil(t, err)
+			assert.Equal(t, want, p)
+		}
+		assert.Equal(t, 0, q.Size())
+	})
+}
+
+func TestPriorityQueue(t *testing.T) {
+	greater := func(a, b int) bool { return a > b }
+
+	t.Run("MaxHeap", func(t *testing.T) {
+		runPriorityQueueTests(t, func() ds.PriorityQueue[int] { return ds.NewMaxHeap[int]() }, greater)
+	})
+	t.Run("MinHeap", func(t *testing.T) {
+		runPriorityQueueTests(t, func() ds.PriorityQueue[int] { return ds.NewMinHeap[int]() }, intLess)
+	})
+	t.Run("Heap", func(t *testing.T) {
+		runPriorityQueueTests(t, func() ds.PriorityQueue[int] { return ds.NewHeap(greater) }, greater)
+	})
+	t.Run("PairingHeap", func(t *testing.T) {
+		runPriorityQueueTests(t, func() ds.PriorityQueue[int] { return ds.NewPairingHeap(intLess) }, intLess)
+	})
+	t.Run("BinomialHeap", func(t *testing.T) {
+		runPriorityQueueTests(t, func() ds.PriorityQueue[int] { return ds.NewBinomialHeap(greater) }, greater)
+	})
+	t.Run("FibonacciHeap", func(t *testing.T) {
+		runPriorityQueueTests(t, func() ds.PriorityQueue[int] { return ds.NewFibonacciHeap(intLess) }, intLess)
+	})
+}
+
+// mergeableHeap is the set of operations shared by the heaps that support Meld and DecreaseKey.
+type mergeableHeap[H any, N any] interface {
+	ds.PriorityQueue[int]
+	Insert(elem int) N
+	DecreaseKey(node N, elem int) error
+	Meld(other H)
+}
+
+// drain pops every element from the queue and returns them in the order they were served.
+func drain(q ds.PriorityQueue[int]) []int {
+	var elems []int
+	for q.Size() > 0 {
+		elem, _ := q.Pop()
+		elems = append(elems, elem)
+	}
+	return elems
+}
+
+// runMergeableHeapTests runs through a set of behaviours common to every mergeable heap; newHeap
+// must return a min heap.
+func runMergeableHeapTests[H mergeableHeap[H, N], N interface{ Value() int }](t *testing.T, newHeap func() H) {
+	t.Run("Insert should return a node holding the element", func(t *testing.T) {
+		h := newHeap()
+		n := h.Insert(10)
+		assert.Equal(t, 10, n.Value())
+		assert.Equal(t, 1, h.Size())
+	})
+
+	t.Run("Meld should move every element into the heap", func(t *testing.T) {
+		h1, h2 := newHeap(), newHeap()
+		for _, e := range []int{5, 1, 9, 3} {
+			h1.Push(e)
+		}
+		for _, e := range []int{8, 2, 7} {
+			h2.Push(e)
+		}
+
+		h1.Meld(h2)
+		assert.Equal(t, 7, h1.Size())
+		assert.Equal(t, 0, h2.Size())
+		_, err := h2.Peek()
+		assert.NotNil(t, err)
+		assert.Equal(t, []int{1, 2, 3, 5, 7, 8, 9}, drain(h1))
+	})
+
+	t.Run("Meld should handle empty heaps", func(t *testing.T) {
+		h1, h2 := newHeap(), newHeap()
+		h1.Meld(h2)
+		assert.Equal(t, 0, h1.Size())
+
+		h2.Push(4)
+		h1.Meld(h2)
+		h1.Meld(newHeap())
+		assert.Equal(t, []int{4}, drain(h1))
+	})
+
+	t.Run("Melding a heap into itself should do nothing", func(t *testing.T) {
+		h := newHeap()
+		h.Push(2)
+		h.Push(1)
+		h.Meld(h)
+		assert.Equal(t, []int{1, 2}, drain(h))
+	})
+
+	t.Run("DecreaseKey should move the element towards the top", func(t *testing.T) {
+		h := newHeap()
+		var nodes []N
+		for i := range 100 {
+			nodes = append(nodes, h.Insert(100+i))
+		}
+		h.Pop()
+
+		assert.Nil(t, h.DecreaseKey(nodes[50], 3))
+		assert.Equal(t, 3, nodes[50].Value())
+		top, _ := h.Peek()
+		assert.Equal(t, 3, top)
+		assert.Nil(t, h.DecreaseKey(nodes[99], 4))
+		assert.Nil(t, h.DecreaseKey(nodes[75], 75))
+
+		elems := drain(h)
+		assert.Equal(t, []int{3, 4, 75}, elems[:3])
+		assert.True(t, slices.IsSorted(elems))
+		assert.Len(t, elems, 99)
+	})
+
+	t.Run("DecreaseKey should accept an equal element", func(t *testing.T) {
+		h := newHeap()
+		n := h.Insert(10)
+		assert.Nil(t, h.DecreaseKey(n, 10))
+	})
+
+	t.Run("DecreaseKey should reject an element further from the top", func(t *testing.T) {
+		h := newHeap()
+		n := h.Insert(10)
+		h.Push(20)
+		assert.NotNil(t, h.DecreaseKey(n, 30))
+		assert.Equal(t, []int{10, 20}, drain(h))
+	})
+
+	t.Run("DecreaseKey should reject a popped node", func(t *testing.T) {
+		h := newHeap()
+		n := h.Insert(10)
+		h.Push(20)
+		h.Pop()
+		assert.NotNil(t, h.DecreaseKey(n, 1))
+		assert.Equal(t, []int{20}, drain(h))
+	})
+
+	t.Run("DecreaseKey should work on nodes from a melded heap", func(t *testing.T) {
+		h1, h2 := newHeap(), newHeap()
+		for i := range 10 {
+			h1.Push(10 + i)
+		}
+		n := h2.Insert(50)
+		h2.Push(60)
+		h1.Meld(h2)
+
+		assert.Nil(t, h1.DecreaseKey(n, 1))
+		top, _ := h1.Peek()
+		assert.Equal(t, 1, top)
+	})
+
+	t.Run("Should match a sorted slice over random operations", func(t *testing.T) {
+		r := rand.New(rand.NewSource(8))
+		h := newHeap()
+		// elements are kept distinct so that a popped element identifies its node
+		live := map[int]N{}
+		next := 1_000_000
+
+		for range 5000 {
+			switch op := r.Intn(10); {
+			case op < 4:
+				next -= r.Intn(5) + 1
+				elem := next + r.Intn(2_000_000)
+				if _, ok := live[elem]; !ok {
+					live[elem] = h.Insert(elem)
+				}
+			case op < 7 && len(live) > 0:
+				// decrease a random element to a fresh, smaller value
+				elem := slices.Sorted(maps.Keys(live))[r.Intn(len(live))]
+				next--
+				smaller := min(next, elem-1)
+				for _, ok := live[smaller]; ok; _, ok = live[smaller] {
+					smaller--
+				}
+				n := live[elem]
+				assert.Nil(t, h.DecreaseKey(n, smaller))
+				delete(live, elem)
+				live[smaller] = n
+			case op < 9 && len(live) > 0:
+				elem, err := h.Pop()
+				assert.Nil(t, err)
+				assert.Equal(t, slices.Min(slices.Collect(maps.Keys(live))), elem)
+				delete(live, elem)
+			default:
+				other := newHeap()
+				for range r.Intn(20) {
+					elem := next + r.Intn(2_000_000)
+					if _, ok := live[elem]; !ok {
+						live[elem] = other.Insert(elem)
+					}
+				}
+				h.Meld(other)
+			}
+			assert.Equal(t, len(live), h.Size())
+		}
+
+		assert.Equal(t, slices.Sorted(maps.Keys(live)), drain(h))
+	})
+}
+
+func TestPairingHeap(t *testing.T) {
+	runMergeableHeapTests(t, func() *ds.PairingHeap[int] { return ds.NewPairingHeap(intLess) })
+}
+
+func TestBinomialHeap(t *testing.T) {
+	runMergeableHeapTests(t, func() *ds.BinomialHeap[int] { return ds.NewBinomialHeap(intLess) })
+}
+
+func TestFibonacciHeap(t *testing.T) {
+	runMergeableHeapTests(t, func() *ds.FibonacciHeap[int] { return ds.NewFibonacciHeap(intLess) })
+}
+
+func TestFibonacciHeapCascadingCut(t *testing.T) {
+	t.Run("Should keep the heap ordered after cutting marked ancestors", func(t *testing.T) {
+		h := ds.NewFibonacciHeap(intLess)
+		var nodes []*ds.FibonacciHeapNode[int]
+		for i := range 64 {
+			nodes = append(nodes, h.Insert(i+1))
+		}
+		// popping consolidates the remaining 63 nodes into deep trees
+		h.Pop()
+
+		// decreasing nodes from the bottom of the heap cuts them and marks (then cuts) their parents
+		for i := 63; i > 0; i -= 2 {
+			assert.Nil(t, h.DecreaseKey(nodes[i], -i))
+		}
+
+		elems := drain(h)
+		assert.Len(t, elems, 63)
+		assert.True(t, slices.IsSortedFunc(elems, cmp.Compare[int]))
+		assert.Equal(t, -63, elems[0])
+	})
+}
+
+/* Benchmarks
+------------------------------------------------------------------------------------------------- */
+
+const (
+	benchmarkShards     = 64
+	benchmarkShardElems = 1_000
+)
+
+// benchmarkShardElements returns the elements held by each of the shards melded by the benchmarks.
+func benchmarkShardElements() [][]int {
+	r := rand.New(rand.NewSource(1))
+	shards := make([][]int, benchmarkShards)
+	for i := range shards {
+		shards[i] = make([]int, benchmarkShardElems)
+		for j := range shards[i] {
+			shards[i][j] = r.Int()
+		}
+	}
+	return shards
+}
+
+// benchmarkMeld fills one heap per shard, then times melding every shard into the first and popping
+// one element per shard off the top, as a merge of per-shard queues would.
+func benchmarkMeld[H mergeableHeap[H, N], N any](b *testing.B, newHeap func() H) {
+	shards := benchmarkShardElements()
+	b.ResetTimer()
+	for range b.N {
+		b.StopTimer()
+		heaps := make([]H, len(shards))
+		for i, shard := range shards {
+			heaps[i] = newHeap()
+			for _, e := range shard {
+				heaps[i].Push(e)
+			}
+		}
+		b.StartTimer()
+		for _, h := range heaps[1:] {
+			heaps[0].Meld(h)
+		}
+		for range benchmarkShards {
+			heaps[0].Pop()
+		}
+	}
+}
+
+func BenchmarkMeld(b *testing.B) {
+	b.Run("MaxHeap", func(b *testing.B) {
+		// MaxHeap has no Meld, so merging costs a Pop and Push per element
+		shards := benchmarkShardElements()
+		b.ResetTimer()
+		for range b.N {
+			b.StopTimer()
+			heaps := make([]*ds.MaxHeap[int], len(shards))
+			for i, shard := range shards {
+				heaps[i] = ds.NewMaxHeap[int]()
+				for _, e := range shard {
+					heaps[i].Push(e)
+				}
+			}
+			b.StartTimer()
+			for _, h := range heaps[1:] {
+				for h.Size() > 0 {
+					e, _ := h.Pop()
+					heaps[0].Push(e)
+				}
+			}
+			for range benchmarkShards {
+				heaps[0].Pop()
+			}
+		}
+	})
+	b.Run("PairingHeap", func(b *testing.B) {
+		benchmarkMeld(b, func() *ds.PairingHeap[int] { return ds.NewPairingHeap(intLess) })
+	})
+	b.Run("BinomialHeap", func(b *testing.B) {
+		benchmarkMeld(b, func() *ds.BinomialHeap[int] { return ds.NewBinomialHeap(intLess) })
+	})
+	b.Run("FibonacciHeap", func(b *testing.B) {
+		benchmarkMeld(b, func() *ds.FibonacciHeap[int] { return ds.NewFibonacciHeap(intLess) })
+	})
+}
+
+// benchmarkDecreaseKey inserts a batch of elements and then decreases every key, popping after each
+// decrease as Dijkstra's algorithm would.
+func benchmarkDecreaseKey[H mergeableHeap[H, N], N any](b *testing.B, newHeap func() H) {
+	elems := rand.New(rand.NewSource(1)).Perm(10_000)
+	b.ResetTimer()
+	for range b.N {
+		h := newHeap()
+		nodes := make([]N, len(elems))
+		for i, e := range elems {
+			nodes[i] = h.Insert(e + len(elems))
+		}
+		for i, e := range elems {
+			h.DecreaseKey(nodes[i], e)
+			if i%2 == 0 {
+				h.Pop()
+			}
+		}
+	}
+}
+
+func BenchmarkDecreaseKey(b *testing.B) {
+	b.Run("PairingHeap", func(b *testing.B) {
+		benchmarkDecreaseKey(b, func() *ds.PairingHeap[int] { return ds.NewPairingHeap(intLess) })
+	})
+	b.Run("BinomialHeap", func(b *testing.B) {
+		benchmarkDecreaseKey(b, func() *ds.BinomialHeap[int] { return ds.NewBinomialHeap(intLess) })
+	})
+	b.Run("FibonacciHeap", func(b *testing.B) {
+		benchmarkDecreaseKey(b, func() *ds.FibonacciHeap[int] { return ds.NewFibonacciHeap(intLess) })
+	})
+}
@@ -0,0 +1,227 @@
+package ds
+
+import (
+	"errors"
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MinMaxHeap implements a [min-max heap][0], a double-ended priority queue that gives access to
+// both its least and greatest elements. It is a complete tree in which the levels alternate between
+// min levels (starting with the root) and max levels: an element on a min level is no greater than
+// any of its descendants and an element on a max level is no less than any of its descendants. The
+// least element is therefore at the root and the greatest is one of the root's children.
+//
+// [0]: https://en.wikipedia.org/wiki/Min-max_heap
+type MinMaxHeap[T any] struct {
+	// s holds the complete tree in level order starting at index 1, as with Heap; s[0] is unused
+	s    []T
+	less func(a, b T) bool
+}
+
+// NewMinMaxHeap returns a new empty min-max heap of ordered elements
+func NewMinMaxHeap[T constraints.Ordered]() *MinMaxHeap[T] {
+	return NewMinMaxHeapFunc(orderedLess[T])
+}
+
+// NewMinMaxHeapFunc returns a new empty min-max heap ordered by the given less function
+func NewMinMaxHeapFunc[T any](less func(a, b T) bool) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{
+		s:    make([]T, 1, 6),
+		less: less,
+	}
+}
+
+// MinMaxHeapify converts a copy of the given list into a valid min-max heap in O(n) time.
+func MinMaxHeapify[T constraints.Ordered](list []T) *MinMaxHeap[T] {
+	return MinMaxHeapifyFunc(list, orderedLess[T])
+}
+
+// MinMaxHeapifyFunc converts a copy of the given list into a valid min-max heap ordered by the
+// given less function in O(n) time.
+func MinMaxHeapifyFunc[T any](list []T, less func(a, b T) bool) *MinMaxHeap[T] {
+	h := NewMinMaxHeapFunc(less)
+	h.s = make([]T, len(list)+1)
+	copy(h.s[1:], list)
+	// as with Heap, each subtree is already valid by the time its root is trickled down
+	for i := h.Size() / 2; i > 0; i-- {
+		h.trickleDown(i)
+	}
+	return h
+}
+
+// Size returns the number of elements currently in the heap
+func (h MinMaxHeap[T]) Size() int {
+	return len(h.s) - 1
+}
+
+// PeekMin returns the least element in the heap without removing it
+func (h MinMaxHeap[T]) PeekMin() (T, error) {
+	if h.Size() == 0 {
+		var empty T
+		return empty, errors.New("cannot peek an empty heap")
+	}
+	return h.s[1], nil
+}
+
+// PeekMax returns the greatest element in the heap without removing it
+func (h MinMaxHeap[T]) PeekMax() (T, error) {
+	if h.Size() == 0 {
+		var empty T
+		return empty, errors.New("cannot peek an empty heap")
+	}
+	return h.s[h.maxIndex()], nil
+}
+
+// Push adds an element to the heap in O(log n) time and maintains the heap property
+func (h *MinMaxHeap[T]) Push(elem T) {
+	h.s = append(h.s, elem)
+	i := h.Size()
+	if i == 1 {
+		return
+	}
+
+	// An element only needs to move along the min levels or the max levels of its path to the root;
+	// comparing it with its parent tells us which.
+	p := parentIndex(i)
+	if isMinLevel(i) {
+		if h.less(h.s[p], h.s[i]) {
+			h.swap(i, p)
+			h.bubbleUp(p, h.greater)
+		} else {
+			h.bubbleUp(i, h.less)
+		}
+	} else {
+		if h.less(h.s[i], h.s[p]) {
+			h.swap(i, p)
+			h.bubbleUp(p, h.less)
+		} else {
+			h.bubbleUp(i, h.greater)
+		}
+	}
+}
+
+// PopMin removes and returns the least element in the heap in O(log n) time
+func (h *MinMaxHeap[T]) PopMin() (T, error) {
+	if h.Size() == 0 {
+		var empty T
+		return empty, errors.New("cannot pop an empty heap")
+	}
+	return h.removeAt(1), nil
+}
+
+// PopMax removes and returns the greatest element in the heap in O(log n) time
+func (h *MinMaxHeap[T]) PopMax() (T, error) {
+	if h.Size() == 0 {
+		var empty T
+		return empty, errors.New("cannot pop an empty heap")
+	}
+	return h.removeAt(h.maxIndex()), nil
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// maxIndex returns the index of the greatest element, which is the root if it has no children and
+// the greater of its children otherwise
+func (h MinMaxHeap[T]) maxIndex() int {
+	switch h.Size() {
+	case 1:
+		return 1
+	case 2:
+		return 2
+	}
+	if h.less(h.s[2], h.s[3]) {
+		return 3
+	}
+	return 2
+}
+
+// removeAt removes and returns the element at the given index, moving the last element into its
+// place and restoring the heap property below it
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	var empty T
+	elem := h.s[i]
+	last := h.Size()
+	h.s[i] = h.s[last]
+	h.s[last] = empty
+	h.s = h.s[:last]
+	if i < last {
+		h.trickleDown(i)
+	}
+
+	return elem
+}
+
+// trickleDown moves the element at the given index down the min levels or the max levels below it,
+// depending on which kind of level it is on, until the heap property is satisfied
+func (h *MinMaxHeap[T]) trickleDown(i int) {
+	if isMinLevel(i) {
+		h.trickleDownFunc(i, h.less)
+	} else {
+		h.trickleDownFunc(i, h.greater)
+	}
+}
+
+// trickleDownFunc moves the element at the given index down its levels of the heap, where before
+// reports whether an element belongs above another on those levels (less for min levels and greater
+// for max levels)
+func (h *MinMaxHeap[T]) trickleDownFunc(i int, before func(a, b T) bool) {
+	last := h.Size()
+	for leftChildIndex(i) <= last {
+		// find the first of the node's children and grandchildren
+		m := leftChildIndex(i)
+		for _, c := range [...]int{
+			rightChildIndex(i),
+			leftChildIndex(leftChildIndex(i)), rightChildIndex(leftChildIndex(i)),
+			leftChildIndex(rightChildIndex(i)), rightChildIndex(rightChildIndex(i)),
+		} {
+			if c <= last && before(h.s[c], h.s[m]) {
+				m = c
+			}
+		}
+
+		if !before(h.s[m], h.s[i]) {
+			// nodes satisfy the heap property
+			return
+		}
+		h.swap(i, m)
+		if m <= rightChildIndex(i) {
+			// a child sits on the opposite kind of level and has no descendants on ours
+			return
+		}
+		// the element swapped into the grandchild may belong on the level in between
+		if p := parentIndex(m); before(h.s[p], h.s[m]) {
+			h.swap(m, p)
+		}
+		i = m
+	}
+}
+
+// bubbleUp swaps the element at the given index with its grandparent while before reports that it
+// belongs above it
+func (h *MinMaxHeap[T]) bubbleUp(i int, before func(a, b T) bool) {
+	for gp := parentIndex(parentIndex(i)); gp > 0; gp = parentIndex(parentIndex(i)) {
+		if !before(h.s[i], h.s[gp]) {
+			break
+		}
+		h.swap(i, gp)
+		i = gp
+	}
+}
+
+// greater reports whether a belongs after b in the heap's order
+func (h *MinMaxHeap[T]) greater(a, b T) bool {
+	return h.less(b, a)
+}
+
+// swap exchanges the elements at the given indices
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.s[i], h.s[j] = h.s[j], h.s[i]
+}
+
+// isMinLevel reports whether the node at the given index is on a min level, i.e. an even depth
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i))%2 == 1
+}
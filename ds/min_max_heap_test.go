@@ -0,0 +1,143 @@
+package ds_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMinMaxHeap(t *testing.T) {
+	t.Run("Should create a new heap of size 0", func(t *testing.T) {
+		h := ds.NewMinMaxHeap[int]()
+		assert.Equal(t, 0, h.Size())
+
+		_, err := h.PeekMin()
+		assert.NotNil(t, err)
+		_, err = h.PeekMax()
+		assert.NotNil(t, err)
+		_, err = h.PopMin()
+		assert.NotNil(t, err)
+		_, err = h.PopMax()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestMinMaxHeapPush(t *testing.T) {
+	t.Run("Should track both ends of the heap", func(t *testing.T) {
+		h := ds.NewMinMaxHeap[int]()
+		for _, tc := range []struct{ elem, min, max int }{
+			{10, 10, 10},
+			{5, 5, 10},
+			{20, 5, 20},
+			{7, 5, 20},
+			{1, 1, 20},
+			{30, 1, 30},
+		} {
+			h.Push(tc.elem)
+			min, _ := h.PeekMin()
+			max, _ := h.PeekMax()
+			assert.Equal(t, tc.min, min)
+			assert.Equal(t, tc.max, max)
+		}
+		assert.Equal(t, 6, h.Size())
+	})
+}
+
+func TestMinMaxHeapPop(t *testing.T) {
+	t.Run("Should pop from either end", func(t *testing.T) {
+		h := ds.NewMinMaxHeap[int]()
+		for _, e := range []int{4, 9, 2, 7, 5, 1, 8} {
+			h.Push(e)
+		}
+
+		min, err := h.PopMin()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, min)
+		max, err := h.PopMax()
+		assert.Nil(t, err)
+		assert.Equal(t, 9, max)
+		max, _ = h.PopMax()
+		assert.Equal(t, 8, max)
+		min, _ = h.PopMin()
+		assert.Equal(t, 2, min)
+		assert.Equal(t, 3, h.Size())
+	})
+
+	t.Run("Should pop the only element from either end", func(t *testing.T) {
+		h := ds.NewMinMaxHeap[int]()
+		h.Push(3)
+		max, err := h.PopMax()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, max)
+		assert.Equal(t, 0, h.Size())
+	})
+
+	t.Run("Should match a sorted slice over random operations", func(t *testing.T) {
+		r := rand.New(rand.NewSource(23))
+		h := ds.NewMinMaxHeap[int]()
+		var oracle []int
+
+		for range 5000 {
+			switch op := r.Intn(4); {
+			case op < 2 || len(oracle) == 0:
+				elem := r.Intn(500)
+				h.Push(elem)
+				i, _ := slices.BinarySearch(oracle, elem)
+				oracle = slices.Insert(oracle, i, elem)
+			case op == 2:
+				min, err := h.PopMin()
+				assert.Nil(t, err)
+				assert.Equal(t, oracle[0], min)
+				oracle = oracle[1:]
+			default:
+				max, err := h.PopMax()
+				assert.Nil(t, err)
+				assert.Equal(t, oracle[len(oracle)-1], max)
+				oracle = oracle[:len(oracle)-1]
+			}
+
+			assert.Equal(t, len(oracle), h.Size())
+			if len(oracle) > 0 {
+				min, _ := h.PeekMin()
+				max, _ := h.PeekMax()
+				assert.Equal(t, oracle[0], min)
+				assert.Equal(t, oracle[len(oracle)-1], max)
+			}
+		}
+	})
+}
+
+func TestMinMaxHeapify(t *testing.T) {
+	t.Run("Should build a valid heap without altering the list", func(t *testing.T) {
+		list := rand.New(rand.NewSource(5)).Perm(1000)
+		original := slices.Clone(list)
+		h := ds.MinMaxHeapify(list)
+		assert.Equal(t, original, list, "Should not have side effects")
+		assert.Equal(t, 1000, h.Size())
+
+		for i := range 500 {
+			min, _ := h.PopMin()
+			max, _ := h.PopMax()
+			assert.Equal(t, i, min)
+			assert.Equal(t, 999-i, max)
+		}
+		assert.Equal(t, 0, h.Size())
+	})
+
+	t.Run("Should build a heap ordered by the less function", func(t *testing.T) {
+		tasks := []task{{"a", 9}, {"b", 3}, {"c", 7}, {"d", 1}, {"e", 8}}
+		h := ds.MinMaxHeapifyFunc(tasks, byDeadline)
+
+		min, _ := h.PeekMin()
+		max, _ := h.PeekMax()
+		assert.Equal(t, "d", min.name)
+		assert.Equal(t, "a", max.name)
+
+		h.Push(task{"f", 12})
+		max, _ = h.PopMax()
+		assert.Equal(t, "f", max.name)
+	})
+}
@@ -0,0 +1,156 @@
+package ds
+
+import (
+	"errors"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// OrderedMap is a sorted dictionary backed by an AVLTree, which keeps the keys in sorted order and
+// provides the ordered queries (Floor, Ceiling, Range, ...). Each value is stored on the tree node
+// holding its key, so a single O(log n) search finds both.
+type OrderedMap[K constraints.Ordered, V any] struct {
+	tree *AVLTree[K]
+}
+
+// NewOrderedMap returns an empty OrderedMap.
+func NewOrderedMap[K constraints.Ordered, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		tree: NewAVLTree[K](),
+	}
+}
+
+// Len returns the number of key/value pairs stored in the map.
+func (m OrderedMap[K, V]) Len() int {
+	return m.tree.Size()
+}
+
+// Put associates the value with the given key, replacing any value previously stored under the key.
+// Keys that cannot be ordered (a floating point NaN) are rejected with an error.
+func (m *OrderedMap[K, V]) Put(key K, value V) error {
+	if key != key {
+		// NaN compares unequal to everything, itself included, so it has no place in the tree and
+		// could never be found again
+		return errors.New("key cannot be ordered")
+	}
+	if node := m.tree.contains(m.tree.Root, key); node != nil {
+		node.value = value
+		return nil
+	}
+
+	root, err := m.tree.insert(m.tree.Root, key, value)
+	if err != nil {
+		return err
+	}
+	m.tree.Root = root
+	m.tree.size++
+
+	return nil
+}
+
+// Get returns the value stored under the given key; if the key is not found an error is returned.
+func (m OrderedMap[K, V]) Get(key K) (V, error) {
+	node := m.tree.contains(m.tree.Root, key)
+	if node == nil {
+		var empty V
+		return empty, errors.New("key not found")
+	}
+
+	return nodeValue[K, V](node), nil
+}
+
+// Contains returns true if a value is stored under the given key; else it returns false.
+func (m OrderedMap[K, V]) Contains(key K) bool {
+	return m.tree.Contains(key)
+}
+
+// Delete removes the given key and its value from the map; if the key is not found an error is
+// returned.
+func (m *OrderedMap[K, V]) Delete(key K) error {
+	return m.tree.Remove(key)
+}
+
+// Min returns the smallest key in the map and its value; if the map is empty an error is returned.
+func (m OrderedMap[K, V]) Min() (K, V, error) {
+	return m.entry(m.tree.findMin(m.tree.Root), nil)
+}
+
+// Max returns the largest key in the map and its value; if the map is empty an error is returned.
+func (m OrderedMap[K, V]) Max() (K, V, error) {
+	return m.entry(m.tree.findMax(m.tree.Root), nil)
+}
+
+// Floor returns the largest key less than or equal to the given key and its value; if there is no
+// such key an error is returned.
+func (m OrderedMap[K, V]) Floor(key K) (K, V, error) {
+	return m.entry(treeNeighborNode(m.tree.Root, key, true, true))
+}
+
+// Ceiling returns the smallest key greater than or equal to the given key and its value; if there
+// is no such key an error is returned.
+func (m OrderedMap[K, V]) Ceiling(key K) (K, V, error) {
+	return m.entry(treeNeighborNode(m.tree.Root, key, false, true))
+}
+
+// Lower returns the largest key strictly less than the given key and its value; if there is no such
+// key an error is returned.
+func (m OrderedMap[K, V]) Lower(key K) (K, V, error) {
+	return m.entry(treeNeighborNode(m.tree.Root, key, true, false))
+}
+
+// Higher returns the smallest key strictly greater than the given key and its value; if there is no
+// such key an error is returned.
+func (m OrderedMap[K, V]) Higher(key K) (K, V, error) {
+	return m.entry(treeNeighborNode(m.tree.Root, key, false, false))
+}
+
+// All returns an iterator over every key/value pair in ascending key order. The map must not be
+// modified during iteration.
+func (m OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.tree.rangeNodes(m.tree.Root, nil, nil, pairYielder(yield))
+	}
+}
+
+// Range returns an iterator over the key/value pairs with keys between lo and hi (inclusive) in
+// ascending key order. The map must not be modified during iteration.
+func (m OrderedMap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.tree.rangeNodes(m.tree.Root, &lo, &hi, pairYielder(yield))
+	}
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// entry returns the key and value held by a node found by one of the tree's queries.
+func (m OrderedMap[K, V]) entry(node *AVLTreeNode[K], err error) (K, V, error) {
+	var emptyKey K
+	var emptyValue V
+	if err != nil {
+		return emptyKey, emptyValue, err
+	}
+	if node == nil {
+		return emptyKey, emptyValue, errors.New("map is empty")
+	}
+
+	return node.Elem, nodeValue[K, V](node), nil
+}
+
+// nodeValue returns the map value carried by the node.
+func nodeValue[K constraints.Ordered, V any](node *AVLTreeNode[K]) V {
+	// a nil interface value is stored as a nil payload, which the comma-ok form turns back into V's
+	// zero value
+	value, _ := node.value.(V)
+	return value
+}
+
+// pairYielder adapts a key/value yield function to the nodes visited by the tree's traversal.
+func pairYielder[K constraints.Ordered, V any](
+	yield func(K, V) bool,
+) func(*AVLTreeNode[K]) bool {
+	return func(node *AVLTreeNode[K]) bool {
+		return yield(node.Elem, nodeValue[K, V](node))
+	}
+}
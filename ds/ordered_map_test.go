@@ -0,0 +1,156 @@
+package ds_test
+
+import (
+	"maps"
+	"math"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrderedMap(t *testing.T) {
+	t.Run("Should create an empty map", func(t *testing.T) {
+		m := ds.NewOrderedMap[int, string]()
+		assert.Equal(t, 0, m.Len())
+		_, _, err := m.Min()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestOrderedMapPutGet(t *testing.T) {
+	t.Run("Should store and replace values", func(t *testing.T) {
+		m := ds.NewOrderedMap[string, int]()
+		m.Put("b", 2)
+		m.Put("a", 1)
+		m.Put("b", 20)
+		assert.Equal(t, 2, m.Len())
+
+		v, err := m.Get("b")
+		assert.Nil(t, err)
+		assert.Equal(t, 20, v)
+		assert.True(t, m.Contains("a"))
+		assert.False(t, m.Contains("c"))
+
+		_, err = m.Get("c")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Should store nil interface values", func(t *testing.T) {
+		m := ds.NewOrderedMap[int, error]()
+		assert.Nil(t, m.Put(1, nil))
+
+		v, err := m.Get(1)
+		assert.Nil(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("Should reject keys that cannot be ordered", func(t *testing.T) {
+		m := ds.NewOrderedMap[float64, string]()
+		assert.Nil(t, m.Put(1.5, "a"))
+		assert.NotNil(t, m.Put(math.NaN(), "nan"))
+		assert.NotNil(t, m.Put(math.NaN(), "nan"))
+
+		assert.Equal(t, 1, m.Len())
+		assert.Equal(t, map[float64]string{1.5: "a"}, maps.Collect(m.All()))
+	})
+}
+
+func TestOrderedMapDelete(t *testing.T) {
+	t.Run("Should remove the key and its value", func(t *testing.T) {
+		m := ds.NewOrderedMap[int, string]()
+		m.Put(1, "one")
+		m.Put(2, "two")
+
+		assert.Nil(t, m.Delete(1))
+		assert.NotNil(t, m.Delete(1))
+		assert.Equal(t, 1, m.Len())
+		assert.False(t, m.Contains(1))
+
+		k, v, err := m.Min()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, k)
+		assert.Equal(t, "two", v)
+	})
+	t.Run("Should keep every value with its key as the tree rebalances", func(t *testing.T) {
+		m := ds.NewOrderedMap[int, int]()
+		for i := range 100 {
+			m.Put(i, i*10)
+		}
+		// removing even keys promotes successors into the places of removed inner nodes
+		for i := 0; i < 100; i += 2 {
+			assert.Nil(t, m.Delete(i))
+		}
+
+		assert.Equal(t, 50, m.Len())
+		for k, v := range m.All() {
+			assert.Equal(t, 1, k%2)
+			assert.Equal(t, k*10, v)
+		}
+		v, err := m.Get(51)
+		assert.Nil(t, err)
+		assert.Equal(t, 510, v)
+	})
+}
+
+func TestOrderedMapQueries(t *testing.T) {
+	m := ds.NewOrderedMap[int, string]()
+	m.Put(30, "thirty")
+	m.Put(10, "ten")
+	m.Put(20, "twenty")
+
+	t.Run("Min and Max", func(t *testing.T) {
+		k, v, err := m.Min()
+		assert.Nil(t, err)
+		assert.Equal(t, 10, k)
+		assert.Equal(t, "ten", v)
+
+		k, v, err = m.Max()
+		assert.Nil(t, err)
+		assert.Equal(t, 30, k)
+		assert.Equal(t, "thirty", v)
+	})
+
+	t.Run("Floor, Ceiling, Lower and Higher", func(t *testing.T) {
+		k, v, err := m.Floor(25)
+		assert.Nil(t, err)
+		assert.Equal(t, 20, k)
+		assert.Equal(t, "twenty", v)
+
+		k, _, err = m.Ceiling(20)
+		assert.Nil(t, err)
+		assert.Equal(t, 20, k)
+
+		k, _, err = m.Lower(20)
+		assert.Nil(t, err)
+		assert.Equal(t, 10, k)
+
+		k, _, err = m.Higher(20)
+		assert.Nil(t, err)
+		assert.Equal(t, 30, k)
+
+		_, v, err = m.Higher(30)
+		assert.NotNil(t, err)
+		assert.Zero(t, v)
+	})
+
+	t.Run("All should iterate in key order", func(t *testing.T) {
+		var keys []int
+		for k := range m.All() {
+			keys = append(keys, k)
+		}
+		assert.Equal(t, []int{10, 20, 30}, keys)
+		assert.Equal(t, map[int]string{10: "ten", 20: "twenty", 30: "thirty"}, maps.Collect(m.All()))
+	})
+
+	t.Run("Range should iterate between two keys", func(t *testing.T) {
+		var keys []int
+		var values []string
+		for k, v := range m.Range(15, 30) {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		assert.Equal(t, []int{20, 30}, keys)
+		assert.Equal(t, []string{"twenty", "thirty"}, values)
+	})
+}
@@ -0,0 +1,189 @@
+package ds
+
+import (
+	"cmp"
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Node pages start with a fixed-size header: a page type byte, a padding byte, the number of keys
+// as a uint16, 4 reserved bytes and the page number of the next leaf as a uint64. The header is
+// followed by (key, value) pairs in a leaf page, or by the first child page number followed by
+// (key, child) pairs in an internal page. Every field is little-endian.
+const (
+	diskLeafPage       byte = 1
+	diskInternalPage   byte = 2
+	diskNodeHeaderSize      = 16
+)
+
+// diskNode is the decoded form of a B+Tree node page, as held in the page cache.
+type diskNode struct {
+	id   uint64
+	leaf bool
+	keys []int64
+	// values holds the value for each key of a leaf
+	values []int64
+	// children holds the len(keys)+1 child page numbers of an internal node
+	children []uint64
+	// next is the page number of the next leaf, or 0 for the last leaf
+	next uint64
+	// dirty is set when the node has changed since it was last written to the data file
+	dirty bool
+}
+
+// pageCache is a fixed-capacity cache of decoded node pages with least-recently-used eviction.
+// Dirty pages are never evicted, since the data file doesn't hold their latest contents until the
+// next Sync; the cache may grow beyond its capacity until then.
+type pageCache struct {
+	file     *os.File
+	pageSize int
+	capacity int
+	pages    map[uint64]*list.Element
+	// lru orders the cached nodes from most (front) to least (back) recently used
+	lru *list.List
+}
+
+// newPageCache returns an empty cache of pages read from the given file.
+func newPageCache(file *os.File, pageSize int, capacity int) *pageCache {
+	return &pageCache{
+		file:     file,
+		pageSize: pageSize,
+		capacity: capacity,
+		pages:    make(map[uint64]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Len returns the number of pages held in the cache.
+func (c pageCache) Len() int {
+	return c.lru.Len()
+}
+
+// get returns the node stored in the given page, reading and decoding the page if it isn't cached.
+func (c *pageCache) get(id uint64) (*diskNode, error) {
+	if elem, ok := c.pages[id]; ok {
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*diskNode), nil
+	}
+
+	buf := make([]byte, c.pageSize)
+	if _, err := c.file.ReadAt(buf, int64(id)*int64(c.pageSize)); err != nil {
+		return nil, fmt.Errorf("reading page %d: %w", id, err)
+	}
+	node, err := decodeDiskNode(id, buf)
+	if err != nil {
+		return nil, err
+	}
+	c.pages[id] = c.lru.PushFront(node)
+
+	return node, nil
+}
+
+// add caches a newly allocated node; the node is marked dirty since it isn't in the data file yet.
+func (c *pageCache) add(node *diskNode) {
+	node.dirty = true
+	c.pages[node.id] = c.lru.PushFront(node)
+}
+
+// dirtyNodes returns every dirty node in the cache, ordered by page number.
+func (c pageCache) dirtyNodes() []*diskNode {
+	var nodes []*diskNode
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		if node := elem.Value.(*diskNode); node.dirty {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.SortFunc(nodes, func(a, b *diskNode) int {
+		return cmp.Compare(a.id, b.id)
+	})
+
+	return nodes
+}
+
+// evict drops the least recently used clean pages until the cache is back within its capacity or
+// only dirty pages remain.
+func (c *pageCache) evict() {
+	elem := c.lru.Back()
+	for c.lru.Len() > c.capacity && elem != nil {
+		prev := elem.Prev()
+		if node := elem.Value.(*diskNode); !node.dirty {
+			c.lru.Remove(elem)
+			delete(c.pages, node.id)
+		}
+		elem = prev
+	}
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// encode writes the node into the page-sized buffer.
+func (n *diskNode) encode(buf []byte) {
+	clear(buf)
+	if n.leaf {
+		buf[0] = diskLeafPage
+	} else {
+		buf[0] = diskInternalPage
+	}
+	binary.LittleEndian.PutUint16(buf[2:], uint16(len(n.keys)))
+	binary.LittleEndian.PutUint64(buf[8:], n.next)
+
+	off := diskNodeHeaderSize
+	if !n.leaf {
+		binary.LittleEndian.PutUint64(buf[off:], n.children[0])
+		off += 8
+	}
+	for i, key := range n.keys {
+		binary.LittleEndian.PutUint64(buf[off:], uint64(key))
+		if n.leaf {
+			binary.LittleEndian.PutUint64(buf[off+8:], uint64(n.values[i]))
+		} else {
+			binary.LittleEndian.PutUint64(buf[off+8:], n.children[i+1])
+		}
+		off += 16
+	}
+}
+
+// decodeDiskNode decodes the node stored in the page-sized buffer.
+func decodeDiskNode(id uint64, buf []byte) (*diskNode, error) {
+	node := &diskNode{
+		id:   id,
+		leaf: buf[0] == diskLeafPage,
+		next: binary.LittleEndian.Uint64(buf[8:]),
+	}
+	if buf[0] != diskLeafPage && buf[0] != diskInternalPage {
+		return nil, fmt.Errorf("page %d is not a node page", id)
+	}
+
+	count := int(binary.LittleEndian.Uint16(buf[2:]))
+	off := diskNodeHeaderSize
+	if !node.leaf {
+		off += 8
+	}
+	if off+count*16 > len(buf) {
+		return nil, errors.New("node page is corrupt")
+	}
+
+	node.keys = make([]int64, count)
+	if node.leaf {
+		node.values = make([]int64, count)
+	} else {
+		node.children = make([]uint64, count+1)
+		node.children[0] = binary.LittleEndian.Uint64(buf[diskNodeHeaderSize:])
+	}
+	for i := range count {
+		node.keys[i] = int64(binary.LittleEndian.Uint64(buf[off:]))
+		if node.leaf {
+			node.values[i] = int64(binary.LittleEndian.Uint64(buf[off+8:]))
+		} else {
+			node.children[i+1] = binary.LittleEndian.Uint64(buf[off+8:])
+		}
+		off += 16
+	}
+
+	return node, nil
+}
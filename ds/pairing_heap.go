@@ -0,0 +1,177 @@
+package ds
+
+import "errors"
+
+// PairingHeapNode is an element of a PairingHeap. The node returned when an element is inserted is
+// used to decrease the element's key later on.
+type PairingHeapNode[T any] struct {
+	elem T
+	// child is the leftmost child of the node
+	child *PairingHeapNode[T]
+	// next is the sibling to the right of the node
+	next *PairingHeapNode[T]
+	// prev is the sibling to the left of the node, or its parent if it is the leftmost child
+	prev *PairingHeapNode[T]
+	// removed is set once the node has been popped from the heap
+	removed bool
+}
+
+// Value returns the element stored in the node.
+func (n PairingHeapNode[T]) Value() T {
+	return n.elem
+}
+
+// PairingHeap is a heap-ordered multiway tree ordered by a less function. Inserting, melding and
+// decreasing a key all take O(1) time by linking two trees together; the work of restructuring the
+// tree is deferred to Pop, which takes O(log n) amortized time.
+type PairingHeap[T any] struct {
+	root *PairingHeapNode[T]
+	size int
+	less func(a, b T) bool
+}
+
+// NewPairingHeap returns a new empty heap ordered by the given less function, which reports whether
+// a belongs closer to the top of the heap than b.
+func NewPairingHeap[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{
+		root: nil,
+		size: 0,
+		less: less,
+	}
+}
+
+// Size returns the number of elements currently in the heap
+func (h PairingHeap[T]) Size() int {
+	return h.size
+}
+
+// Peek returns the element at the top of the heap without removing it
+func (h PairingHeap[T]) Peek() (T, error) {
+	if h.root == nil {
+		var empty T
+		return empty, errors.New("cannot peek an empty heap")
+	}
+	return h.root.elem, nil
+}
+
+// Push adds an element to the heap in O(1) time
+func (h *PairingHeap[T]) Push(elem T) {
+	h.Insert(elem)
+}
+
+// Insert adds an element to the heap in O(1) time and returns the node holding it, which can be
+// passed to DecreaseKey.
+func (h *PairingHeap[T]) Insert(elem T) *PairingHeapNode[T] {
+	node := &PairingHeapNode[T]{elem: elem}
+	h.root = h.link(h.root, node)
+	h.size++
+
+	return node
+}
+
+// Pop removes the element at the top of the heap in O(log n) amortized time. The children of the
+// removed root are linked together in pairs from left to right, and the resulting trees are then
+// linked together from right to left.
+func (h *PairingHeap[T]) Pop() (T, error) {
+	if h.root == nil {
+		var empty T
+		return empty, errors.New("cannot pop an empty heap")
+	}
+
+	root := h.root
+	// first pass: link the children together in pairs
+	var pairs []*PairingHeapNode[T]
+	for child := root.child; child != nil; {
+		a, b := child, child.next
+		if b == nil {
+			a.prev = nil
+			pairs = append(pairs, a)
+			break
+		}
+		child = b.next
+		a.prev, a.next, b.prev, b.next = nil, nil, nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+	// second pass: link the pairs together from the right
+	h.root = nil
+	for i := len(pairs) - 1; i >= 0; i-- {
+		h.root = h.link(pairs[i], h.root)
+	}
+
+	h.size--
+	root.child = nil
+	root.removed = true
+
+	return root.elem, nil
+}
+
+// DecreaseKey replaces the node's element with one that is no further from the top of the heap and
+// moves the node up if needed, in O(1) time. If the new element belongs further from the top of the
+// heap than the current one, or the node is no longer in the heap, an error is returned. The node
+// must have been inserted into this heap or into a heap that has since been melded into it.
+func (h *PairingHeap[T]) DecreaseKey(node *PairingHeapNode[T], elem T) error {
+	if node.removed {
+		return errors.New("node is not in the heap")
+	}
+	if h.less(node.elem, elem) {
+		return errors.New("new element belongs further from the top of the heap")
+	}
+
+	node.elem = elem
+	if node == h.root {
+		return nil
+	}
+
+	// cut the node and its subtree out of its parent's children and link it back in at the root
+	if node.prev.child == node {
+		node.prev.child = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	}
+	node.prev, node.next = nil, nil
+	h.root = h.link(h.root, node)
+
+	return nil
+}
+
+// Meld moves every element of the other heap into this heap in O(1) time, leaving the other heap
+// empty. Both heaps must be ordered by the same less function.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// link makes the root that belongs lower in the heap the leftmost child of the other root and
+// returns the root of the combined tree. Either root may be nil.
+func (h *PairingHeap[T]) link(a, b *PairingHeapNode[T]) *PairingHeapNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.elem, a.elem) {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+
+	return a
+}
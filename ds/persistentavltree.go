@@ -0,0 +1,218 @@
+package ds
+
+import (
+	"errors"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// PersistentAVLTree is an immutable AVL tree. Insert and Remove leave the tree they are called on
+// untouched and return a new version of the tree instead. Each new version copies only the nodes on
+// the path from the root to the change (plus the few nodes involved in rebalancing) and shares
+// every other subtree with the previous version, so creating a version costs O(log n) time and
+// memory. Because nodes are never modified once they are part of a version, any number of versions
+// can be read concurrently from multiple goroutines without locking.
+type PersistentAVLTree[T constraints.Ordered] struct {
+	root *AVLTreeNode[T]
+	size int
+}
+
+// NewPersistentAVLTree returns a pointer to a new, empty Persistent AVL Tree.
+func NewPersistentAVLTree[T constraints.Ordered]() *PersistentAVLTree[T] {
+	return &PersistentAVLTree[T]{
+		root: nil,
+		size: 0,
+	}
+}
+
+// Size returns the number of nodes in this version of the tree.
+func (t PersistentAVLTree[T]) Size() int {
+	return t.size
+}
+
+// Insert returns a new version of the tree that also holds the given element. If the element is
+// already in the tree an error is returned along with the unchanged tree.
+func (t *PersistentAVLTree[T]) Insert(elem T) (*PersistentAVLTree[T], error) {
+	root, err := t.insert(t.root, elem)
+	if err != nil {
+		return t, err
+	}
+
+	return &PersistentAVLTree[T]{root: root, size: t.size + 1}, nil
+}
+
+// Remove returns a new version of the tree without the given element. If the element is not in the
+// tree an error is returned along with the unchanged tree.
+func (t *PersistentAVLTree[T]) Remove(elem T) (*PersistentAVLTree[T], error) {
+	root, err := t.remove(t.root, elem)
+	if err != nil {
+		return t, err
+	}
+
+	return &PersistentAVLTree[T]{root: root, size: t.size - 1}, nil
+}
+
+// Contains returns true if the specified element is in this version of the tree; else it returns
+// false.
+func (t PersistentAVLTree[T]) Contains(elem T) bool {
+	return t.view().Contains(elem)
+}
+
+// Min returns the smallest element in the tree; if the tree is empty an error is returned.
+func (t PersistentAVLTree[T]) Min() (T, error) {
+	return t.view().Min()
+}
+
+// Max returns the largest element in the tree; if the tree is empty an error is returned.
+func (t PersistentAVLTree[T]) Max() (T, error) {
+	return t.view().Max()
+}
+
+// Floor returns the largest element in the tree that is less than or equal to the given element; if
+// there is no such element an error is returned.
+func (t PersistentAVLTree[T]) Floor(elem T) (T, error) {
+	return t.view().Floor(elem)
+}
+
+// Ceiling returns the smallest element in the tree that is greater than or equal to the given
+// element; if there is no such element an error is returned.
+func (t PersistentAVLTree[T]) Ceiling(elem T) (T, error) {
+	return t.view().Ceiling(elem)
+}
+
+// Select returns the element with the given 0-based rank; if k is out of range an error is
+// returned.
+func (t PersistentAVLTree[T]) Select(k int) (T, error) {
+	return t.view().Select(k)
+}
+
+// Rank returns the number of elements in the tree that are strictly less than the given element.
+func (t PersistentAVLTree[T]) Rank(elem T) int {
+	return t.view().Rank(elem)
+}
+
+// All returns an iterator over every element in this version of the tree in ascending order.
+func (t PersistentAVLTree[T]) All() iter.Seq[T] {
+	return t.view().All()
+}
+
+// Range returns an iterator over the elements in this version of the tree between lo and hi
+// (inclusive) in ascending order.
+func (t PersistentAVLTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return t.view().Range(lo, hi)
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// view wraps the version's nodes in an AVLTree so that its read-only queries can be reused. The view
+// must never be modified since its nodes are shared with other versions.
+func (t PersistentAVLTree[T]) view() AVLTree[T] {
+	return AVLTree[T]{Root: t.root, size: t.size}
+}
+
+// insert returns the root of a copy of the subtree that also holds the element. Only the nodes on
+// the path to the new leaf are copied.
+func (t PersistentAVLTree[T]) insert(root *AVLTreeNode[T], elem T) (*AVLTreeNode[T], error) {
+	if root == nil {
+		return NewAVLTreeNode(elem), nil
+	}
+
+	if elem < root.Elem {
+		node, err := t.insert(root.Left, elem)
+		if err != nil {
+			return nil, err
+		}
+		root = cloneAVLTreeNode(root)
+		root.Left = node
+	} else if elem > root.Elem {
+		node, err := t.insert(root.Right, elem)
+		if err != nil {
+			return nil, err
+		}
+		root = cloneAVLTreeNode(root)
+		root.Right = node
+	} else {
+		return nil, errors.New("cannot insert a duplicate element")
+	}
+
+	return t.balance(root), nil
+}
+
+// remove returns the root of a copy of the subtree without the element. Only the nodes on the path
+// to the removed node (and to its successor) are copied.
+func (t PersistentAVLTree[T]) remove(root *AVLTreeNode[T], elem T) (*AVLTreeNode[T], error) {
+	if root == nil {
+		return nil, errors.New("element not found in the tree")
+	}
+
+	if elem < root.Elem {
+		node, err := t.remove(root.Left, elem)
+		if err != nil {
+			return nil, err
+		}
+		root = cloneAVLTreeNode(root)
+		root.Left = node
+	} else if elem > root.Elem {
+		node, err := t.remove(root.Right, elem)
+		if err != nil {
+			return nil, err
+		}
+		root = cloneAVLTreeNode(root)
+		root.Right = node
+	} else {
+		// we've found the node to be removed
+		if root.Left == nil {
+			return root.Right, nil
+		}
+		if root.Right == nil {
+			return root.Left, nil
+		}
+		// Replace the node with a copy of the minimum node in the right subtree
+		var ops AVLTree[T]
+		min := ops.findMin(root.Right)
+		right, err := t.remove(root.Right, min.Elem)
+		if err != nil {
+			return nil, err
+		}
+		left := root.Left
+		root = cloneAVLTreeNode(min)
+		root.Left = left
+		root.Right = right
+	}
+
+	return t.balance(root), nil
+}
+
+// balance rebalances a freshly copied subtree root using the AVLTree balance and rotation logic.
+// Rotations modify the heavy child (and, for a double rotation, the heavy child's inner child), so
+// those nodes are copied first when they may still be shared with other versions.
+func (t PersistentAVLTree[T]) balance(root *AVLTreeNode[T]) *AVLTreeNode[T] {
+	root.recalculate()
+
+	bFactor := root.GetBalanceFactor()
+	if bFactor < -1 {
+		root.Right = cloneAVLTreeNode(root.Right)
+		if root.Right.GetBalanceFactor() > 0 {
+			root.Right.Left = cloneAVLTreeNode(root.Right.Left)
+		}
+	} else if bFactor > 1 {
+		root.Left = cloneAVLTreeNode(root.Left)
+		if root.Left.GetBalanceFactor() < 0 {
+			root.Left.Right = cloneAVLTreeNode(root.Left.Right)
+		}
+	}
+
+	var ops AVLTree[T]
+	root = ops.balance(root)
+	root.recalculate()
+
+	return root
+}
+
+// cloneAVLTreeNode returns a shallow copy of the node; the copy shares the node's children.
+func cloneAVLTreeNode[T constraints.Ordered](node *AVLTreeNode[T]) *AVLTreeNode[T] {
+	clone := *node
+	return &clone
+}
@@ -0,0 +1,250 @@
+package ds_test
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// checkAVLNodes verifies the ordering, cached heights, sizes and balance of every node in the
+// subtree and returns the subtree's height (-1 for an empty subtree).
+func checkAVLNodes(t *testing.T, n *ds.AVLTreeNode[int]) int {
+	if n == nil {
+		return -1
+	}
+	lh := checkAVLNodes(t, n.Left)
+	rh := checkAVLNodes(t, n.Right)
+	if n.Left != nil {
+		assert.Less(t, n.Left.Elem, n.Elem)
+	}
+	if n.Right != nil {
+		assert.Greater(t, n.Right.Elem, n.Elem)
+	}
+	assert.LessOrEqual(t, lh-rh, 1)
+	assert.GreaterOrEqual(t, lh-rh, -1)
+	assert.Equal(t, max(lh, rh)+1, n.GetHeight())
+
+	size := 1
+	if n.Left != nil {
+		size += n.Left.GetSize()
+	}
+	if n.Right != nil {
+		size += n.Right.GetSize()
+	}
+	assert.Equal(t, size, n.GetSize())
+
+	return n.GetHeight()
+}
+
+// collectAVLNodes returns the set of nodes reachable from the given root.
+func collectAVLNodes(n *ds.AVLTreeNode[int], nodes map[*ds.AVLTreeNode[int]]bool) {
+	if n == nil {
+		return
+	}
+	nodes[n] = true
+	collectAVLNodes(n.Left, nodes)
+	collectAVLNodes(n.Right, nodes)
+}
+
+func TestPersistentAVLTreeInsert(t *testing.T) {
+	t.Run("Should return a new version containing the element", func(t *testing.T) {
+		v0 := ds.NewPersistentAVLTree[int]()
+		v1, err := v0.Insert(5)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, v0.Size())
+		assert.False(t, v0.Contains(5))
+		assert.Equal(t, 1, v1.Size())
+		assert.True(t, v1.Contains(5))
+	})
+
+	t.Run("Should return the unchanged tree and an error on duplicates", func(t *testing.T) {
+		v1, _ := ds.NewPersistentAVLTree[int]().Insert(5)
+		v2, err := v1.Insert(5)
+		assert.NotNil(t, err)
+		assert.Same(t, v1, v2)
+		assert.Equal(t, 1, v2.Size())
+	})
+
+	t.Run("Should leave every previous version unchanged", func(t *testing.T) {
+		versions := []*ds.PersistentAVLTree[int]{ds.NewPersistentAVLTree[int]()}
+		for i := range 200 {
+			next, err := versions[i].Insert(i)
+			assert.Nil(t, err)
+			versions = append(versions, next)
+		}
+
+		for i, v := range versions {
+			assert.Equal(t, i, v.Size())
+			checkAVLNodes(t, ds.PersistentAVLTreeRoot(v))
+			want := []int{}
+			for j := range i {
+				want = append(want, j)
+			}
+			assert.Equal(t, want, append([]int{}, slices.Collect(v.All())...))
+		}
+	})
+
+	t.Run("Should share unchanged subtrees with the previous version", func(t *testing.T) {
+		v := ds.NewPersistentAVLTree[int]()
+		for i := range 1024 {
+			v, _ = v.Insert(i * 2)
+		}
+		next, err := v.Insert(501)
+		assert.Nil(t, err)
+
+		old := map[*ds.AVLTreeNode[int]]bool{}
+		collectAVLNodes(ds.PersistentAVLTreeRoot(v), old)
+		nodes := map[*ds.AVLTreeNode[int]]bool{}
+		collectAVLNodes(ds.PersistentAVLTreeRoot(next), nodes)
+
+		copied := 0
+		for n := range nodes {
+			if !old[n] {
+				copied++
+			}
+		}
+		// only the search path (and a few rotated nodes) should have been copied
+		assert.LessOrEqual(t, copied, 2*ds.PersistentAVLTreeRoot(next).GetHeight())
+	})
+}
+
+func TestPersistentAVLTreeRemove(t *testing.T) {
+	t.Run("Should return an error when the element is not in the tree", func(t *testing.T) {
+		v0 := ds.NewPersistentAVLTree[int]()
+		v1, err := v0.Remove(1)
+		assert.NotNil(t, err)
+		assert.Same(t, v0, v1)
+	})
+
+	t.Run("Should return a new version without the element", func(t *testing.T) {
+		v := ds.NewPersistentAVLTree[int]()
+		for _, e := range []int{50, 25, 75, 10, 30, 60, 90} {
+			v, _ = v.Insert(e)
+		}
+		// removing the root exercises the two-children case
+		next, err := v.Remove(50)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{10, 25, 30, 60, 75, 90}, slices.Collect(next.All()))
+		assert.Equal(t, []int{10, 25, 30, 50, 60, 75, 90}, slices.Collect(v.All()))
+		checkAVLNodes(t, ds.PersistentAVLTreeRoot(next))
+		checkAVLNodes(t, ds.PersistentAVLTreeRoot(v))
+	})
+
+	t.Run("Should match a sorted slice across random operations", func(t *testing.T) {
+		r := rand.New(rand.NewSource(14))
+		type snapshot struct {
+			tree *ds.PersistentAVLTree[int]
+			want []int
+		}
+		v := ds.NewPersistentAVLTree[int]()
+		want := []int{}
+		snapshots := []snapshot{}
+
+		for range 2000 {
+			e := r.Intn(300)
+			i, found := slices.BinarySearch(want, e)
+			if r.Intn(2) == 0 {
+				next, err := v.Insert(e)
+				assert.Equal(t, found, err != nil)
+				if !found {
+					want = slices.Insert(slices.Clone(want), i, e)
+				}
+				v = next
+			} else {
+				next, err := v.Remove(e)
+				assert.Equal(t, !found, err != nil)
+				if found {
+					want = slices.Delete(slices.Clone(want), i, i+1)
+				}
+				v = next
+			}
+			snapshots = append(snapshots, snapshot{tree: v, want: want})
+		}
+
+		for _, s := range snapshots {
+			assert.Equal(t, len(s.want), s.tree.Size())
+			assert.Equal(t, s.want, append([]int{}, slices.Collect(s.tree.All())...))
+		}
+		checkAVLNodes(t, ds.PersistentAVLTreeRoot(v))
+	})
+}
+
+func TestPersistentAVLTreeQueries(t *testing.T) {
+	v := ds.NewPersistentAVLTree[int]()
+	for _, e := range []int{10, 20, 30, 40, 50} {
+		v, _ = v.Insert(e)
+	}
+
+	t.Run("Should answer ordered queries", func(t *testing.T) {
+		min, err := v.Min()
+		assert.Nil(t, err)
+		assert.Equal(t, 10, min)
+		max, err := v.Max()
+		assert.Nil(t, err)
+		assert.Equal(t, 50, max)
+		floor, err := v.Floor(35)
+		assert.Nil(t, err)
+		assert.Equal(t, 30, floor)
+		ceiling, err := v.Ceiling(35)
+		assert.Nil(t, err)
+		assert.Equal(t, 40, ceiling)
+		_, err = v.Ceiling(51)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Should answer order statistic queries", func(t *testing.T) {
+		e, err := v.Select(2)
+		assert.Nil(t, err)
+		assert.Equal(t, 30, e)
+		assert.Equal(t, 3, v.Rank(35))
+		assert.Equal(t, []int{20, 30, 40}, slices.Collect(v.Range(15, 45)))
+	})
+
+	t.Run("Should report errors on an empty tree", func(t *testing.T) {
+		empty := ds.NewPersistentAVLTree[int]()
+		_, err := empty.Min()
+		assert.NotNil(t, err)
+		_, err = empty.Select(0)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestPersistentAVLTreeConcurrentReaders(t *testing.T) {
+	t.Run("Should allow old versions to be read while new versions are created", func(t *testing.T) {
+		base := ds.NewPersistentAVLTree[int]()
+		for i := range 500 {
+			base, _ = base.Insert(i)
+		}
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 20 {
+					count := 0
+					for range base.All() {
+						count++
+					}
+					assert.Equal(t, 500, count)
+					assert.True(t, base.Contains(250))
+				}
+			}()
+		}
+
+		v := base
+		for i := range 500 {
+			v, _ = v.Remove(i)
+			v, _ = v.Insert(i + 1000)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 500, base.Size())
+		assert.Equal(t, 500, v.Size())
+		assert.False(t, v.Contains(250))
+	})
+}
@@ -0,0 +1,11 @@
+package ds
+
+// PriorityQueue is the set of operations shared by the heaps in this package. Every implementation
+// serves its elements in the order defined by the heap, with the element at the top of the heap
+// returned first by Peek and Pop.
+type PriorityQueue[T any] interface {
+	Push(elem T)
+	Pop() (T, error)
+	Peek() (T, error)
+	Size() int
+}
@@ -0,0 +1,413 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+type rbColor uint8
+
+const (
+	red rbColor = iota
+	black
+)
+
+// RedBlackTreeNode is an implementation of a node to be used on red-black trees.
+type RedBlackTreeNode[T constraints.Ordered] struct {
+	Elem   T
+	Left   *RedBlackTreeNode[T]
+	Right  *RedBlackTreeNode[T]
+	parent *RedBlackTreeNode[T]
+	color  rbColor
+}
+
+// IsRed returns true if the node is colored red and false if it is colored black.
+func (n RedBlackTreeNode[T]) IsRed() bool {
+	return n.color == red
+}
+
+// RedBlackTree is an implementation of a self-balancing binary search tree that colors each node
+// red or black and maintains the following properties:
+//
+//   - the root is black
+//   - a red node never has a red child
+//   - every path from a node down to a leaf passes through the same number of black nodes
+//
+// Together these keep the tree within a factor of two of perfect balance. The balance is looser
+// than an AVLTree's, so lookups may visit a few more nodes, but inserts and removes perform fewer
+// rotations (at most two and three respectively).
+type RedBlackTree[T constraints.Ordered] struct {
+	Root      *RedBlackTreeNode[T]
+	size      int
+	rotations int
+}
+
+// NewRedBlackTree returns a pointer to a new Red-Black Tree.
+func NewRedBlackTree[T constraints.Ordered]() *RedBlackTree[T] {
+	return &RedBlackTree[T]{
+		Root: nil,
+		size: 0,
+	}
+}
+
+// Size returns the number of nodes in the tree.
+func (t RedBlackTree[T]) Size() int {
+	return t.size
+}
+
+// Contains returns true if the specified element is in the tree; else it returns false.
+func (t RedBlackTree[T]) Contains(elem T) bool {
+	return t.find(elem) != nil
+}
+
+// Insert adds an element into the tree and maintains the binary search and red-black properties.
+func (t *RedBlackTree[T]) Insert(elem T) error {
+	var parent *RedBlackTreeNode[T]
+	node := t.Root
+
+	// find the leaf position for the new element
+	for node != nil {
+		parent = node
+		if elem < node.Elem {
+			node = node.Left
+		} else if elem > node.Elem {
+			node = node.Right
+		} else {
+			return errors.New("cannot insert a duplicate element")
+		}
+	}
+
+	// new nodes are red so that black heights are unaffected
+	node = &RedBlackTreeNode[T]{Elem: elem, parent: parent, color: red}
+	if parent == nil {
+		t.Root = node
+	} else if elem < parent.Elem {
+		parent.Left = node
+	} else {
+		parent.Right = node
+	}
+	t.size++
+
+	t.insertFixup(node)
+
+	return nil
+}
+
+// Remove removes an element from the tree and maintains the binary search and red-black properties.
+func (t *RedBlackTree[T]) Remove(elem T) error {
+	node := t.find(elem)
+	if node == nil {
+		return errors.New("element not found in the tree")
+	}
+
+	// removedColor is the color of the node that is physically unlinked from the tree; x is the node
+	// that takes its place (possibly nil) and xParent is x's new parent
+	removedColor := node.color
+	var x, xParent *RedBlackTreeNode[T]
+
+	if node.Left == nil {
+		x = node.Right
+		xParent = node.parent
+		t.transplant(node, node.Right)
+	} else if node.Right == nil {
+		x = node.Left
+		xParent = node.parent
+		t.transplant(node, node.Left)
+	} else {
+		// Promote the minimum node in the right subtree, which takes on the removed node's color
+		successor := node.Right
+		for successor.Left != nil {
+			successor = successor.Left
+		}
+		removedColor = successor.color
+		x = successor.Right
+
+		if successor.parent == node {
+			xParent = successor
+		} else {
+			xParent = successor.parent
+			t.transplant(successor, successor.Right)
+			successor.Right = node.Right
+			successor.Right.parent = successor
+		}
+
+		t.transplant(node, successor)
+		successor.Left = node.Left
+		successor.Left.parent = successor
+		successor.color = node.color
+	}
+	t.size--
+
+	if removedColor == black {
+		// a black node was unlinked, leaving its paths one black node short
+		t.removeFixup(x, xParent)
+	}
+
+	return nil
+}
+
+// Validate checks that the tree satisfies the binary search and red-black properties and that the
+// number of reachable nodes matches Size. An error describing the first violation found is
+// returned.
+func (t RedBlackTree[T]) Validate() error {
+	if t.Root == nil {
+		if t.size != 0 {
+			return fmt.Errorf("tree is empty but its size is %d", t.size)
+		}
+		return nil
+	}
+
+	if t.Root.color != black {
+		return errors.New("root is not black")
+	}
+	if t.Root.parent != nil {
+		return errors.New("root has a parent")
+	}
+
+	count := 0
+	if _, err := t.validate(t.Root, nil, nil, &count); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("tree has %d nodes but its size is %d", count, t.size)
+	}
+
+	return nil
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+func (t RedBlackTree[T]) find(elem T) *RedBlackTreeNode[T] {
+	node := t.Root
+
+	for node != nil {
+		if elem < node.Elem {
+			node = node.Left
+		} else if elem > node.Elem {
+			node = node.Right
+		} else {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// insertFixup restores the red-black properties after inserting the given red node, which may
+// have a red parent.
+func (t *RedBlackTree[T]) insertFixup(node *RedBlackTreeNode[T]) {
+	for isRed(node.parent) {
+		parent := node.parent
+		// the parent is red so it cannot be the root; the grandparent exists
+		grandparent := parent.parent
+
+		if parent == grandparent.Left {
+			uncle := grandparent.Right
+			if isRed(uncle) {
+				// recolor and continue fixing up from the grandparent
+				parent.color = black
+				uncle.color = black
+				grandparent.color = red
+				node = grandparent
+				continue
+			}
+			if node == parent.Right {
+				// the node is an 'inner' grandchild; rotate it to the outside first
+				node = parent
+				t.leftRotation(node)
+				parent = node.parent
+			}
+			parent.color = black
+			grandparent.color = red
+			t.rightRotation(grandparent)
+		} else {
+			uncle := grandparent.Left
+			if isRed(uncle) {
+				// recolor and continue fixing up from the grandparent
+				parent.color = black
+				uncle.color = black
+				grandparent.color = red
+				node = grandparent
+				continue
+			}
+			if node == parent.Left {
+				// the node is an 'inner' grandchild; rotate it to the outside first
+				node = parent
+				t.rightRotation(node)
+				parent = node.parent
+			}
+			parent.color = black
+			grandparent.color = red
+			t.leftRotation(grandparent)
+		}
+	}
+
+	t.Root.color = black
+}
+
+// removeFixup restores the red-black properties after a black node was unlinked. x (which may be
+// nil) carries an 'extra' black that must be pushed up the tree or absorbed by a rotation.
+func (t *RedBlackTree[T]) removeFixup(x, parent *RedBlackTreeNode[T]) {
+	for x != t.Root && !isRed(x) {
+		// x is short a black node, so its sibling's subtree holds at least one black node and the
+		// sibling cannot be nil; that also means a nil x is the left child exactly when
+		// parent.Left is nil
+		if x == parent.Left {
+			sibling := parent.Right
+			if isRed(sibling) {
+				// rotate so that x gets a black sibling
+				sibling.color = black
+				parent.color = red
+				t.leftRotation(parent)
+				sibling = parent.Right
+			}
+			if !isRed(sibling.Left) && !isRed(sibling.Right) {
+				// remove a black from both sides by recoloring and move the problem up the tree
+				sibling.color = red
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if !isRed(sibling.Right) {
+				// make the sibling's red child the 'outer' one
+				sibling.Left.color = black
+				sibling.color = red
+				t.rightRotation(sibling)
+				sibling = parent.Right
+			}
+			sibling.color = parent.color
+			parent.color = black
+			sibling.Right.color = black
+			t.leftRotation(parent)
+			x = t.Root
+		} else {
+			sibling := parent.Left
+			if isRed(sibling) {
+				// rotate so that x gets a black sibling
+				sibling.color = black
+				parent.color = red
+				t.rightRotation(parent)
+				sibling = parent.Left
+			}
+			if !isRed(sibling.Left) && !isRed(sibling.Right) {
+				// remove a black from both sides by recoloring and move the problem up the tree
+				sibling.color = red
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if !isRed(sibling.Left) {
+				// make the sibling's red child the 'outer' one
+				sibling.Right.color = black
+				sibling.color = red
+				t.leftRotation(sibling)
+				sibling = parent.Left
+			}
+			sibling.color = parent.color
+			parent.color = black
+			sibling.Left.color = black
+			t.rightRotation(parent)
+			x = t.Root
+		}
+	}
+
+	if x != nil {
+		x.color = black
+	}
+}
+
+// transplant replaces the subtree rooted at u with the subtree rooted at v (which may be nil).
+func (t *RedBlackTree[T]) transplant(u, v *RedBlackTreeNode[T]) {
+	if u.parent == nil {
+		t.Root = v
+	} else if u == u.parent.Left {
+		u.parent.Left = v
+	} else {
+		u.parent.Right = v
+	}
+
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+// leftRotation will perform a Left Rotation around the given node, updating parent pointers and the
+// tree's root as needed.
+func (t *RedBlackTree[T]) leftRotation(root *RedBlackTreeNode[T]) {
+	node := root.Right
+	root.Right = node.Left
+	if node.Left != nil {
+		node.Left.parent = root
+	}
+	t.transplant(root, node)
+	node.Left = root
+	root.parent = node
+	t.rotations++
+}
+
+// rightRotation will perform a Right Rotation around the given node, updating parent pointers and
+// the tree's root as needed.
+func (t *RedBlackTree[T]) rightRotation(root *RedBlackTreeNode[T]) {
+	node := root.Left
+	root.Left = node.Right
+	if node.Right != nil {
+		node.Right.parent = root
+	}
+	t.transplant(root, node)
+	node.Right = root
+	root.parent = node
+	t.rotations++
+}
+
+// validate recursively checks the subtree rooted at node against the optional (exclusive) lo and hi
+// bounds, counting the nodes it visits. It returns the black height of the subtree.
+func (t RedBlackTree[T]) validate(node *RedBlackTreeNode[T], lo, hi *T, count *int) (int, error) {
+	if node == nil {
+		return 1, nil
+	}
+
+	*count++
+	if *count > t.size {
+		return 0, fmt.Errorf("tree has more than the expected %d nodes (or contains a cycle)", t.size)
+	}
+
+	if (lo != nil && node.Elem <= *lo) || (hi != nil && node.Elem >= *hi) {
+		return 0, fmt.Errorf("element %v violates the binary search property", node.Elem)
+	}
+	for _, child := range []*RedBlackTreeNode[T]{node.Left, node.Right} {
+		if child == nil {
+			continue
+		}
+		if child.parent != node {
+			return 0, fmt.Errorf("element %v has an incorrect parent pointer", child.Elem)
+		}
+		if node.color == red && child.color == red {
+			return 0, fmt.Errorf("red element %v has a red child %v", node.Elem, child.Elem)
+		}
+	}
+
+	leftHeight, err := t.validate(node.Left, lo, &node.Elem, count)
+	if err != nil {
+		return 0, err
+	}
+	rightHeight, err := t.validate(node.Right, &node.Elem, hi, count)
+	if err != nil {
+		return 0, err
+	}
+	if leftHeight != rightHeight {
+		return 0, fmt.Errorf("element %v has unequal black heights (%d and %d)", node.Elem, leftHeight, rightHeight)
+	}
+
+	if node.color == black {
+		return leftHeight + 1, nil
+	}
+	return leftHeight, nil
+}
+
+// isRed returns true if the node is red; nil leaves are black.
+func isRed[T constraints.Ordered](node *RedBlackTreeNode[T]) bool {
+	return node != nil && node.color == red
+}
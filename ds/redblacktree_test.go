@@ -0,0 +1,270 @@
+package ds_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// runSortedSetTests runs through a set of behaviours common to every SortedSet implementation.
+func runSortedSetTests(t *testing.T, newSet func() ds.SortedSet[int]) {
+	t.Run("Should create an empty set", func(t *testing.T) {
+		s := newSet()
+		assert.Equal(t, 0, s.Size())
+		assert.False(t, s.Contains(10))
+	})
+
+	t.Run("Should reject duplicate elements", func(t *testing.T) {
+		s := newSet()
+		assert.Nil(t, s.Insert(10))
+		assert.NotNil(t, s.Insert(10))
+		assert.Equal(t, 1, s.Size())
+	})
+
+	t.Run("Should reject removing a missing element", func(t *testing.T) {
+		s := newSet()
+		assert.NotNil(t, s.Remove(10))
+		s.Insert(5)
+		assert.NotNil(t, s.Remove(10))
+		assert.Equal(t, 1, s.Size())
+	})
+
+	t.Run("Should match a sorted slice over random operations", func(t *testing.T) {
+		r := rand.New(rand.NewSource(13))
+		s := newSet()
+		var oracle []int
+
+		for range 5000 {
+			elem := r.Intn(1000)
+			i, found := slices.BinarySearch(oracle, elem)
+			if r.Intn(2) == 0 {
+				assert.Equal(t, found, s.Insert(elem) != nil)
+				if !found {
+					oracle = slices.Insert(oracle, i, elem)
+				}
+			} else {
+				assert.Equal(t, found, s.Remove(elem) == nil)
+				if found {
+					oracle = slices.Delete(oracle, i, i+1)
+				}
+			}
+		}
+
+		assert.Equal(t, len(oracle), s.Size())
+		for e := range 1000 {
+			_, found := slices.BinarySearch(oracle, e)
+			assert.Equal(t, found, s.Contains(e))
+		}
+	})
+}
+
+func TestAVLTreeSortedSet(t *testing.T) {
+	runSortedSetTests(t, func() ds.SortedSet[int] { return ds.NewAVLTree[int]() })
+}
+
+func TestRedBlackTreeSortedSet(t *testing.T) {
+	runSortedSetTests(t, func() ds.SortedSet[int] { return ds.NewRedBlackTree[int]() })
+}
+
+func TestRedBlackTreeInsert(t *testing.T) {
+	t.Run("The root should always be black", func(t *testing.T) {
+		tree := ds.NewRedBlackTree[int]()
+		tree.Insert(10)
+		assert.False(t, tree.Root.IsRed())
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("Ascending inserts should stay balanced", func(t *testing.T) {
+		tree := ds.NewRedBlackTree[int]()
+		tree.Insert(1)
+		tree.Insert(2)
+		tree.Insert(3)
+
+		assert.Equal(t, 2, tree.Root.Elem)
+		assert.Equal(t, 1, tree.Root.Left.Elem)
+		assert.Equal(t, 3, tree.Root.Right.Elem)
+		assert.True(t, tree.Root.Left.IsRed())
+		assert.True(t, tree.Root.Right.IsRed())
+		assert.Equal(t, 1, ds.RedBlackTreeRotations(tree))
+
+		for i := 4; i <= 1000; i++ {
+			tree.Insert(i)
+		}
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("An inner grandchild should cause a double rotation", func(t *testing.T) {
+		tree := ds.NewRedBlackTree[int]()
+		tree.Insert(10)
+		tree.Insert(5)
+		tree.Insert(7)
+
+		assert.Equal(t, 7, tree.Root.Elem)
+		assert.Equal(t, 5, tree.Root.Left.Elem)
+		assert.Equal(t, 10, tree.Root.Right.Elem)
+		assert.Equal(t, 2, ds.RedBlackTreeRotations(tree))
+		assert.Nil(t, tree.Validate())
+	})
+}
+
+func TestRedBlackTreeRemove(t *testing.T) {
+	t.Run("Removing the only node should empty the tree", func(t *testing.T) {
+		tree := ds.NewRedBlackTree[int]()
+		tree.Insert(10)
+		assert.Nil(t, tree.Remove(10))
+		assert.Nil(t, tree.Root)
+		assert.Equal(t, 0, tree.Size())
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("Removing a node with two children should promote its successor", func(t *testing.T) {
+		tree := ds.NewRedBlackTree[int]()
+		for _, e := range []int{10, 5, 15, 3, 7, 13, 20} {
+			tree.Insert(e)
+		}
+		assert.Nil(t, tree.Remove(10))
+		assert.Equal(t, 13, tree.Root.Elem)
+		assert.False(t, tree.Contains(10))
+		assert.Nil(t, tree.Validate())
+	})
+
+	t.Run("Removing every element in order should keep the tree valid", func(t *testing.T) {
+		tree := ds.NewRedBlackTree[int]()
+		for i := range 500 {
+			tree.Insert(i)
+		}
+		for i := range 500 {
+			assert.Nil(t, tree.Remove(i))
+			assert.Nil(t, tree.Validate())
+		}
+		assert.Nil(t, tree.Root)
+	})
+}
+
+func TestRedBlackTreeValidate(t *testing.T) {
+	newTree := func() *ds.RedBlackTree[int] {
+		tree := ds.NewRedBlackTree[int]()
+		for _, e := range []int{10, 5, 15, 3, 7, 13, 20, 1} {
+			tree.Insert(e)
+		}
+		return tree
+	}
+
+	t.Run("Should detect a broken ordering", func(t *testing.T) {
+		tree := newTree()
+		assert.Nil(t, tree.Validate())
+		tree.Root.Left.Right.Elem = 11
+		assert.NotNil(t, tree.Validate())
+	})
+
+	t.Run("Should detect unequal black heights", func(t *testing.T) {
+		tree := newTree()
+		// 3 is black, so dropping it (and its red child 1) shortens the paths through 5
+		tree.Root.Left.Left = nil
+		err := tree.Validate()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "black heights")
+	})
+
+	t.Run("Should detect a size mismatch", func(t *testing.T) {
+		tree := newTree()
+		// 1 is a red leaf, so removing it only breaks the size
+		tree.Root.Left.Left.Left = nil
+		err := tree.Validate()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "size")
+	})
+}
+
+func TestRedBlackTreeRandomOperations(t *testing.T) {
+	property := func(ops []uint16) bool {
+		tree := ds.NewRedBlackTree[int]()
+		for _, op := range ops {
+			elem := int(op & 255)
+			if op&0x8000 == 0 {
+				tree.Insert(elem)
+			} else {
+				tree.Remove(elem)
+			}
+			if tree.Validate() != nil {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 300}); err != nil {
+		t.Error(err)
+	}
+}
+
+/* Benchmarks
+------------------------------------------------------------------------------------------------- */
+
+var sortedSetBenchmarks = []struct {
+	name      string
+	newSet    func() ds.SortedSet[int]
+	rotations func(ds.SortedSet[int]) int
+}{
+	{
+		"AVLTree",
+		func() ds.SortedSet[int] { return ds.NewAVLTree[int]() },
+		func(s ds.SortedSet[int]) int { return ds.AVLTreeRotations(s.(*ds.AVLTree[int])) },
+	},
+	{
+		"RedBlackTree",
+		func() ds.SortedSet[int] { return ds.NewRedBlackTree[int]() },
+		func(s ds.SortedSet[int]) int { return ds.RedBlackTreeRotations(s.(*ds.RedBlackTree[int])) },
+	},
+}
+
+// benchmarkSortedSet runs the given workload against every SortedSet implementation, reporting the
+// rotations performed per operation alongside the usual timings.
+func benchmarkSortedSet(b *testing.B, elems []int, work func(ds.SortedSet[int], []int) int) {
+	for _, bm := range sortedSetBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			rotations, ops := 0, 0
+			for range b.N {
+				s := bm.newSet()
+				ops += work(s, elems)
+				rotations += bm.rotations(s)
+			}
+			b.ReportMetric(float64(rotations)/float64(ops), "rotations/op")
+		})
+	}
+}
+
+func insertAll(s ds.SortedSet[int], elems []int) int {
+	for _, e := range elems {
+		s.Insert(e)
+	}
+	return len(elems)
+}
+
+func BenchmarkSortedSetRandomInsert(b *testing.B) {
+	elems := rand.New(rand.NewSource(1)).Perm(10_000)
+	benchmarkSortedSet(b, elems, insertAll)
+}
+
+func BenchmarkSortedSetSequentialInsert(b *testing.B) {
+	elems := make([]int, 10_000)
+	for i := range elems {
+		elems[i] = i
+	}
+	benchmarkSortedSet(b, elems, insertAll)
+}
+
+func BenchmarkSortedSetInsertRemove(b *testing.B) {
+	elems := rand.New(rand.NewSource(1)).Perm(10_000)
+	benchmarkSortedSet(b, elems, func(s ds.SortedSet[int], elems []int) int {
+		insertAll(s, elems)
+		for _, e := range elems {
+			s.Remove(e)
+		}
+		return 2 * len(elems)
+	})
+}
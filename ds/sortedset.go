@@ -0,0 +1,16 @@
+package ds
+
+import "golang.org/x/exp/constraints"
+
+// SortedSet is implemented by the self-balancing binary search trees in this package, which store
+// unique elements in sorted order.
+type SortedSet[T constraints.Ordered] interface {
+	// Insert adds an element to the set; an error is returned if the element is already present
+	Insert(elem T) error
+	// Remove removes an element from the set; an error is returned if the element is not present
+	Remove(elem T) error
+	// Contains returns true if the element is in the set
+	Contains(elem T) bool
+	// Size returns the number of elements in the set
+	Size() int
+}
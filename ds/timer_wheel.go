@@ -0,0 +1,324 @@
+package ds
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// timerWheelBits is the number of bits of a timer's expiry tick used to pick its slot at each
+	// level of a TimerWheel
+	timerWheelBits  = 6
+	timerWheelSlots = 1 << timerWheelBits
+	timerWheelMask  = timerWheelSlots - 1
+
+	defaultTimerWheelLevels = 4
+)
+
+// Clock is the source of time for a TimerWheel. It is an interface so that tests can control the
+// passage of time instead of sleeping.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After returns a channel that receives the current time once the given duration has elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// WheelTimer is a timer scheduled on a TimerWheel. It is used to stop the timer before it expires.
+type WheelTimer[T any] struct {
+	value T
+	// at is the tick at which the timer expires
+	at      uint64
+	stopped bool
+	// overflowIndex is the timer's position in the wheel's overflow heap, or 0 if it is not there
+	overflowIndex int
+}
+
+// Value returns the value delivered when the timer expires.
+func (t WheelTimer[T]) Value() T {
+	return t.value
+}
+
+// TimerWheelOption configures a TimerWheel at construction time.
+type TimerWheelOption func(*timerWheelConfig)
+
+type timerWheelConfig struct {
+	levels int
+	buffer int
+}
+
+// WithTimerWheelLevels sets the number of levels in the wheel. Each level has 64 slots and covers
+// 64 times the span of the level below it; timers further out than the top level can reach wait in
+// a heap until they come within range. Values less than 1 are ignored.
+func WithTimerWheelLevels(levels int) TimerWheelOption {
+	return func(c *timerWheelConfig) {
+		if levels > 0 {
+			c.levels = levels
+		}
+	}
+}
+
+// WithExpiredBuffer sets the capacity of the channel that expired values are delivered on. Values
+// less than 0 are ignored.
+func WithExpiredBuffer(n int) TimerWheelOption {
+	return func(c *timerWheelConfig) {
+		if n >= 0 {
+			c.buffer = n
+		}
+	}
+}
+
+// TimerWheel is a hierarchical timing wheel (as described by Varghese and Lauck) that tracks large
+// numbers of timeouts, delivering the value of each timer on a channel once it expires. Time is
+// divided into ticks of a fixed duration; scheduling and stopping a timer within the wheel's reach
+// take O(1) time no matter how many timers are pending, at the cost of timers only expiring on tick
+// boundaries.
+//
+// The bottom level of the wheel holds one slot per tick for the next 64 ticks. Each level above
+// holds timers 64 times further out, with each slot covering a whole rotation of the level below;
+// as the wheel turns, the timers in those slots cascade down into finer-grained slots. Timers that
+// are beyond the reach of the top level are kept in a Heap ordered by expiry, where scheduling and
+// stopping them take O(log n) time.
+//
+// The wheel only moves when Advance is called, either directly or by Run. Stopped timers in the
+// wheel's slots are dropped lazily, when the wheel next reaches them; stopped timers in the heap are
+// removed straight away, so cancelling many long timeouts does not hold on to their memory.
+type TimerWheel[T any] struct {
+	mu    sync.Mutex
+	clock Clock
+	tick  time.Duration
+	start time.Time
+	// current is the last tick that has been processed
+	current uint64
+	// slots holds a list of timers per slot per level
+	slots [][]*WheelTimer[T]
+	// overflow holds the timers that are too far out to fit in the wheel
+	overflow *Heap[*WheelTimer[T]]
+	size     int
+
+	// advancing serializes calls to Advance, so that expired values are delivered in order
+	advancing sync.Mutex
+	// pending holds the expired values that have not yet been delivered
+	pending []T
+	expired chan T
+}
+
+// NewTimerWheel returns an empty TimerWheel that reads the time from the given clock and expires
+// timers on boundaries of the given tick duration, configured with the given options. The wheel's
+// first tick starts at the clock's current time. Tick durations less than 1ns are raised to 1ns.
+func NewTimerWheel[T any](clock Clock, tick time.Duration, opts ...TimerWheelOption) *TimerWheel[T] {
+	cfg := timerWheelConfig{
+		levels: defaultTimerWheelLevels,
+		buffer: 0,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	w := &TimerWheel[T]{
+		clock: clock,
+		tick:  max(tick, 1),
+		start: clock.Now(),
+		slots: make([][]*WheelTimer[T], cfg.levels*timerWheelSlots),
+		overflow: NewHeap(func(a, b *WheelTimer[T]) bool {
+			return a.at < b.at
+		}),
+		expired: make(chan T, cfg.buffer),
+	}
+	// track where each timer is in the overflow heap so that stopped timers can be removed from it
+	w.overflow.moved = func(t *WheelTimer[T], i int) {
+		t.overflowIndex = i
+	}
+
+	return w
+}
+
+// Len returns the number of timers that have been scheduled and have neither expired nor been
+// stopped.
+func (w *TimerWheel[T]) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.size
+}
+
+// Expired returns the channel that the value of each timer is delivered on once it expires. Values
+// are delivered in order of expiry (timers expiring on the same tick are delivered in no particular
+// order).
+func (w *TimerWheel[T]) Expired() <-chan T {
+	return w.expired
+}
+
+// Schedule starts a timer that delivers the given value once the delay has elapsed; the value is
+// delivered by the first call to Advance on or after the first tick boundary at or past the delay.
+// Delays of 0 or less expire on the next tick.
+func (w *TimerWheel[T]) Schedule(delay time.Duration, value T) *WheelTimer[T] {
+	deadline := w.clock.Now().Add(delay).Sub(w.start)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// round up so that timers never expire early
+	at := uint64(0)
+	if deadline > 0 {
+		at = uint64((deadline + w.tick - 1) / w.tick)
+	}
+	t := &WheelTimer[T]{
+		value: value,
+		at:    max(at, w.current+1),
+	}
+	w.insert(t)
+	w.size++
+
+	return t
+}
+
+// Stop prevents the timer from expiring. It returns true if the call stops the timer and false if
+// the timer has already expired or been stopped.
+func (w *TimerWheel[T]) Stop(t *WheelTimer[T]) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if t.stopped || t.at <= w.current {
+		return false
+	}
+	t.stopped = true
+	w.size--
+	if t.overflowIndex > 0 {
+		w.overflow.removeAt(t.overflowIndex)
+		t.overflowIndex = 0
+	}
+	// otherwise the timer is dropped lazily when its slot is next visited
+
+	return true
+}
+
+// Advance turns the wheel up to the clock's current time and delivers the value of every timer
+// that has expired on the Expired channel, blocking until each value is received (or buffered). If
+// the context is done first, its error is returned and the undelivered values are kept for the next
+// call to Advance.
+func (w *TimerWheel[T]) Advance(ctx context.Context) error {
+	w.advancing.Lock()
+	defer w.advancing.Unlock()
+
+	now := w.clock.Now().Sub(w.start)
+	w.mu.Lock()
+	if now > 0 {
+		for target := uint64(now / w.tick); w.current < target; {
+			w.current++
+			w.turn()
+		}
+	}
+	w.mu.Unlock()
+
+	for len(w.pending) > 0 {
+		select {
+		case w.expired <- w.pending[0]:
+			var empty T
+			w.pending[0] = empty
+			w.pending = w.pending[1:]
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	w.pending = nil
+
+	return nil
+}
+
+// Run advances the wheel once per tick until the context is done, returning the context's error.
+func (w *TimerWheel[T]) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-w.clock.After(w.tick):
+			if err := w.Advance(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// turn processes the current tick, cascading timers down from the upper levels of the wheel and
+// moving the timers that have expired to the pending list.
+func (w *TimerWheel[T]) turn() {
+	levels := len(w.slots) / timerWheelSlots
+
+	// timers come into range of the wheel one tick at a time
+	for w.overflow.Size() > 0 {
+		t, _ := w.overflow.Peek()
+		if t.at-w.current >= 1<<(timerWheelBits*levels) {
+			break
+		}
+		w.overflow.Pop()
+		t.overflowIndex = 0
+		w.insert(t)
+	}
+
+	// when the bottom level completes a rotation, empty the next slot of the level above into the
+	// levels below, and so on up the wheel for every level that has also completed a rotation
+	var top int
+	for top = 1; top < levels; top++ {
+		if (w.current>>(timerWheelBits*top))<<(timerWheelBits*top) != w.current {
+			break
+		}
+	}
+	for level := top - 1; level > 0; level-- {
+		slot := w.slot(level, w.current)
+		timers := w.slots[slot]
+		w.slots[slot] = nil
+		for _, t := range timers {
+			if !t.stopped {
+				w.insert(t)
+			}
+		}
+	}
+
+	slot := w.slot(0, w.current)
+	for _, t := range w.slots[slot] {
+		if !t.stopped {
+			w.pending = append(w.pending, t.value)
+			w.size--
+		}
+	}
+	w.slots[slot] = nil
+}
+
+// insert places the timer in the lowest level of the wheel that reaches its expiry, or in the
+// overflow heap if it is beyond the reach of the wheel. Timers expiring on the current tick are
+// placed in the current slot of the bottom level.
+func (w *TimerWheel[T]) insert(t *WheelTimer[T]) {
+	levels := len(w.slots) / timerWheelSlots
+	diff := t.at - w.current
+	for level := range levels {
+		if diff < 1<<(timerWheelBits*(level+1)) {
+			slot := w.slot(level, t.at)
+			w.slots[slot] = append(w.slots[slot], t)
+			return
+		}
+	}
+	w.overflow.Push(t)
+}
+
+// slot returns the index in slots of the slot that the given tick falls in at the given level.
+func (w *TimerWheel[T]) slot(level int, tick uint64) int {
+	return level*timerWheelSlots + int((tick>>(timerWheelBits*level))&timerWheelMask)
+}
@@ -0,0 +1,276 @@
+package ds_test
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a ds.Clock whose time only moves when Add is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := fakeClockWaiter{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+	return w.c
+}
+
+// Add moves the clock forward, firing every After channel whose duration has elapsed.
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	waiting := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiting = append(waiting, w)
+		} else {
+			w.c <- c.now
+		}
+	}
+	c.waiters = waiting
+}
+
+// BlockUntil waits until the given number of goroutines are waiting on After channels.
+func (c *fakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// received returns every value that is ready on the channel without blocking.
+func received[T any](c <-chan T) []T {
+	var values []T
+	for {
+		select {
+		case v := <-c:
+			values = append(values, v)
+		default:
+			return values
+		}
+	}
+}
+
+func TestNewTimerWheel(t *testing.T) {
+	t.Run("Should create an empty wheel", func(t *testing.T) {
+		w := ds.NewTimerWheel[int](newFakeClock(), time.Millisecond)
+		assert.Equal(t, 0, w.Len())
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Empty(t, received(w.Expired()))
+	})
+}
+
+func TestTimerWheelSchedule(t *testing.T) {
+	t.Run("Should deliver a value once its delay has elapsed", func(t *testing.T) {
+		clock := newFakeClock()
+		w := ds.NewTimerWheel[string](clock, time.Millisecond, ds.WithExpiredBuffer(10))
+		timer := w.Schedule(5*time.Millisecond, "a")
+		assert.Equal(t, "a", timer.Value())
+		assert.Equal(t, 1, w.Len())
+
+		clock.Add(4 * time.Millisecond)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Empty(t, received(w.Expired()))
+
+		clock.Add(time.Millisecond)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Equal(t, []string{"a"}, received(w.Expired()))
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("Should never deliver a value early", func(t *testing.T) {
+		clock := newFakeClock()
+		w := ds.NewTimerWheel[int](clock, 10*time.Millisecond, ds.WithExpiredBuffer(10))
+		clock.Add(3 * time.Millisecond)
+		w.Schedule(15*time.Millisecond, 1)
+
+		// the deadline is 18ms after the wheel started, so the timer expires on the tick at 20ms
+		clock.Add(15 * time.Millisecond)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Empty(t, received(w.Expired()))
+		clock.Add(2 * time.Millisecond)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Equal(t, []int{1}, received(w.Expired()))
+	})
+
+	t.Run("Should deliver a value with no delay on the next tick", func(t *testing.T) {
+		clock := newFakeClock()
+		w := ds.NewTimerWheel[int](clock, time.Second, ds.WithExpiredBuffer(10))
+		w.Schedule(-time.Minute, 1)
+		w.Schedule(0, 2)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Empty(t, received(w.Expired()))
+
+		clock.Add(time.Second)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.ElementsMatch(t, []int{1, 2}, received(w.Expired()))
+	})
+
+	t.Run("Should deliver values in order of expiry across every level", func(t *testing.T) {
+		clock := newFakeClock()
+		// two levels reach 4096 ticks, so the longest delays wait in the overflow heap
+		w := ds.NewTimerWheel[int](clock, time.Millisecond,
+			ds.WithTimerWheelLevels(2), ds.WithExpiredBuffer(10_000))
+		r := rand.New(rand.NewSource(22))
+
+		expiries := map[int]int{}
+		for i := range 3000 {
+			delay := r.Intn(20_000) + 1
+			expiries[i] = delay
+			w.Schedule(time.Duration(delay)*time.Millisecond, i)
+		}
+		assert.Equal(t, 3000, w.Len())
+
+		elapsed, last := 0, 0
+		for len(expiries) > 0 {
+			step := r.Intn(300) + 1
+			clock.Add(time.Duration(step) * time.Millisecond)
+			elapsed += step
+			assert.Nil(t, w.Advance(context.Background()))
+
+			for _, v := range received(w.Expired()) {
+				assert.LessOrEqual(t, expiries[v], elapsed)
+				assert.GreaterOrEqual(t, expiries[v], last)
+				last = expiries[v]
+				delete(expiries, v)
+			}
+			for v, expiry := range expiries {
+				assert.Greater(t, expiry, elapsed, "value %d was not delivered", v)
+			}
+			assert.Equal(t, len(expiries), w.Len())
+		}
+	})
+}
+
+func TestTimerWheelStop(t *testing.T) {
+	t.Run("Should prevent a timer from expiring", func(t *testing.T) {
+		clock := newFakeClock()
+		w := ds.NewTimerWheel[int](clock, time.Millisecond, ds.WithExpiredBuffer(10))
+		short := w.Schedule(time.Millisecond, 1)
+		long := w.Schedule(time.Hour, 2)
+		w.Schedule(2*time.Millisecond, 3)
+
+		assert.True(t, w.Stop(short))
+		assert.False(t, w.Stop(short))
+		assert.True(t, w.Stop(long))
+		assert.Equal(t, 1, w.Len())
+
+		clock.Add(2 * time.Hour)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Equal(t, []int{3}, received(w.Expired()))
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("Should release stopped timers that are beyond the wheel's reach", func(t *testing.T) {
+		clock := newFakeClock()
+		// one level reaches 64 ticks, so every timer below waits in the overflow heap
+		w := ds.NewTimerWheel[int](clock, time.Millisecond,
+			ds.WithTimerWheelLevels(1), ds.WithExpiredBuffer(10))
+		var timers []*ds.WheelTimer[int]
+		for i := range 1000 {
+			timers = append(timers, w.Schedule(time.Duration(100+i)*time.Millisecond, i))
+		}
+		assert.Equal(t, 1000, ds.TimerWheelOverflowLen(w))
+
+		for i, timer := range timers {
+			if i != 500 {
+				assert.True(t, w.Stop(timer))
+			}
+		}
+		assert.Equal(t, 1, ds.TimerWheelOverflowLen(w))
+		assert.Equal(t, 1, w.Len())
+
+		clock.Add(time.Second)
+		assert.Nil(t, w.Advance(context.Background()))
+		assert.Equal(t, []int{500}, received(w.Expired()))
+		assert.Equal(t, 0, ds.TimerWheelOverflowLen(w))
+		assert.False(t, w.Stop(timers[500]))
+	})
+
+	t.Run("Should not stop a timer that has expired", func(t *testing.T) {
+		clock := newFakeClock()
+		w := ds.NewTimerWheel[int](clock, time.Millisecond, ds.WithExpiredBuffer(10))
+		timer := w.Schedule(time.Millisecond, 1)
+		clock.Add(time.Millisecond)
+		assert.Nil(t, w.Advance(context.Background()))
+
+		assert.False(t, w.Stop(timer))
+		assert.Equal(t, 0, w.Len())
+	})
+}
+
+func TestTimerWheelAdvance(t *testing.T) {
+	t.Run("Should keep undelivered values when the context is done", func(t *testing.T) {
+		clock := newFakeClock()
+		w := ds.NewTimerWheel[int](clock, time.Millisecond)
+		w.Schedule(time.Millisecond, 1)
+		w.Schedule(2*time.Millisecond, 2)
+		clock.Add(2 * time.Millisecond)
+
+		// nobody is receiving from the unbuffered channel
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, w.Advance(ctx), context.Canceled)
+
+		go w.Advance(context.Background())
+		assert.Equal(t, 1, <-w.Expired())
+		assert.Equal(t, 2, <-w.Expired())
+	})
+}
+
+func TestTimerWheelRun(t *testing.T) {
+	t.Run("Should advance the wheel once per tick", func(t *testing.T) {
+		clock := newFakeClock()
+		w := ds.NewTimerWheel[int](clock, time.Second)
+		w.Schedule(3*time.Second, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- w.Run(ctx)
+		}()
+
+		for range 3 {
+			clock.BlockUntil(1)
+			clock.Add(time.Second)
+		}
+		assert.Equal(t, 1, <-w.Expired())
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+}
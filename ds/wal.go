@@ -0,0 +1,131 @@
+package ds
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+)
+
+// Every write-ahead log record has a 13 byte header (a record type byte, a page number as a uint64
+// and the payload length as a uint32), followed by the payload and a CRC-32 checksum of the header
+// and payload. A transaction is a run of page records followed by a commit record; page records
+// that aren't followed by an intact commit record are ignored when the log is recovered.
+const (
+	walPageRecord      byte = 1
+	walCommitRecord    byte = 2
+	walRecordHeaderLen      = 13
+	walChecksumLen          = 4
+)
+
+// walPage is the full image of a single page, as stored in the write-ahead log.
+type walPage struct {
+	id   uint64
+	data []byte
+}
+
+// writeAheadLog makes multi-page writes crash-safe. Page images are appended to the log and synced
+// to disk before any of them are written to the data file, so a crash part way through updating
+// the data file can be repaired by replaying the log.
+type writeAheadLog struct {
+	file *os.File
+	size int64
+}
+
+// openWriteAheadLog opens (or creates) the log file at the given path.
+func openWriteAheadLog(path string) (*writeAheadLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &writeAheadLog{file: file, size: info.Size()}, nil
+}
+
+// commit appends the page images as a single transaction and syncs the log to disk. Once commit
+// returns without error the pages will survive a crash.
+func (l *writeAheadLog) commit(pages []walPage) error {
+	var buf []byte
+	for _, page := range pages {
+		buf = appendWALRecord(buf, walPageRecord, page.id, page.data)
+	}
+	buf = appendWALRecord(buf, walCommitRecord, 0, nil)
+
+	if _, err := l.file.WriteAt(buf, l.size); err != nil {
+		return err
+	}
+	l.size += int64(len(buf))
+
+	return l.file.Sync()
+}
+
+// recover returns the page images of every committed transaction in the log, in the order they
+// were written. Reading stops at the first torn or corrupt record.
+func (l *writeAheadLog) recover() ([]walPage, error) {
+	data := make([]byte, l.size)
+	if _, err := l.file.ReadAt(data, 0); err != nil {
+		return nil, err
+	}
+
+	var committed, pending []walPage
+	for off := 0; len(data)-off >= walRecordHeaderLen+walChecksumLen; {
+		length := int(binary.LittleEndian.Uint32(data[off+9:]))
+		end := off + walRecordHeaderLen + length + walChecksumLen
+		if end > len(data) {
+			break
+		}
+		if crc32.ChecksumIEEE(data[off:end-walChecksumLen]) != binary.LittleEndian.Uint32(data[end-walChecksumLen:]) {
+			break
+		}
+
+		switch data[off] {
+		case walPageRecord:
+			pending = append(pending, walPage{
+				id:   binary.LittleEndian.Uint64(data[off+1:]),
+				data: data[off+walRecordHeaderLen : end-walChecksumLen],
+			})
+		case walCommitRecord:
+			committed = append(committed, pending...)
+			pending = nil
+		default:
+			return committed, nil
+		}
+		off = end
+	}
+
+	return committed, nil
+}
+
+// reset empties the log once its transactions have been applied to the data file.
+func (l *writeAheadLog) reset() error {
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	l.size = 0
+
+	return l.file.Sync()
+}
+
+// close closes the log file.
+func (l *writeAheadLog) close() error {
+	return l.file.Close()
+}
+
+/* Private helper functions
+------------------------------------------------------------------------------------------------- */
+
+// appendWALRecord appends a checksummed record to the buffer.
+func appendWALRecord(buf []byte, kind byte, id uint64, payload []byte) []byte {
+	start := len(buf)
+	buf = append(buf, kind)
+	buf = binary.LittleEndian.AppendUint64(buf, id)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = append(buf, payload...)
+
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf[start:]))
+}
@@ -0,0 +1,208 @@
+package sort
+
+import (
+	"errors"
+
+	"github.com/bcdxn/dsa-go/ds"
+	"golang.org/x/exp/constraints"
+)
+
+// Heap returns a sorted copy of the given list using the [Heap Sort algorithm][0]. This function
+// does not alter the given list.
+//
+// **Time Complexity**
+//
+// ```
+// | Scenario     | Big-O       |
+// |:-------------|:------------|
+// | Average Case | O(n*log(n)) |
+// | Best Case    | O(n*log(n)) |
+// | Worst Case   | O(n*log(n)) |
+// ```
+//
+// Additional Notes:
+// - Space complexity - O(n) for the copy
+//
+// [0]: https://en.wikipedia.org/wiki/Heapsort
+func Heap[T constraints.Ordered](list []T) []T {
+	// Copy the list to ensure there are no side effects on the given list
+	cpy := make([]T, len(list))
+	copy(cpy, list)
+
+	return InPlaceHeap(cpy)
+}
+
+// InPlaceHeap sorts the given list using the [Heap Sort algorithm][0]. This function alters the
+// given list.
+//
+// **Time Complexity**
+//
+// ```
+// | Scenario     | Big-O       |
+// |:-------------|:------------|
+// | Average Case | O(n*log(n)) |
+// | Best Case    | O(n*log(n)) |
+// | Worst Case   | O(n*log(n)) |
+// ```
+//
+// Additional Notes:
+// - Space complexity - O(1)
+//
+// [0]: https://en.wikipedia.org/wiki/Heapsort
+func InPlaceHeap[T constraints.Ordered](list []T) []T {
+	// Turn the list into a max heap in O(n) time
+	ds.HeapifySlice(list, greaterThan[T])
+	// Each pop moves the maximum into the slot at the end of the heap, shrinking the heap by one
+	for end := len(list); end > 1; end-- {
+		ds.PopHeapSlice(list[:end], greaterThan[T])
+	}
+
+	return list
+}
+
+// TopK returns the k largest elements of the given list, largest first. If k is larger than the
+// list, every element is returned. This function does not alter the given list.
+//
+// **Time Complexity**
+//
+// ```
+// | Scenario     | Big-O       |
+// |:-------------|:------------|
+// | Average Case | O(n*log(k)) |
+// | Best Case    | O(n)        |
+// | Worst Case   | O(n*log(k)) |
+// ```
+//
+// Additional Notes:
+// - Space complexity - O(k)
+func TopK[T constraints.Ordered](list []T, k int) []T {
+	k = max(min(k, len(list)), 0)
+	cpy := make([]T, k)
+	if k == 0 {
+		return cpy
+	}
+
+	// Keep the k largest elements seen so far in a min heap, so the smallest of them is at hand to be
+	// evicted; HeapifyFunc copies the first k elements, so there are no side effects on the list
+	h := ds.HeapifyFunc(list[:k], lessThan[T])
+	for _, elem := range list[k:] {
+		if top, err := h.Peek(); err == nil && elem > top {
+			_, _ = h.Replace(elem)
+		}
+	}
+
+	// Popping the heap yields the elements smallest first; fill the result from the end
+	for i := k - 1; i >= 0; i-- {
+		cpy[i], _ = h.Pop()
+	}
+
+	return cpy
+}
+
+// InPlaceTopK partially sorts the given list so that it starts with its k largest elements, largest
+// first, and returns that prefix of the list. The remaining elements follow in no particular order.
+// If k is larger than the list, the whole list is sorted. This function alters the given list.
+//
+// **Time Complexity**
+//
+// ```
+// | Scenario     | Big-O       |
+// |:-------------|:------------|
+// | Average Case | O(n*log(k)) |
+// | Best Case    | O(n)        |
+// | Worst Case   | O(n*log(k)) |
+// ```
+//
+// Additional Notes:
+// - Space complexity - O(1)
+func InPlaceTopK[T constraints.Ordered](list []T, k int) []T {
+	k = max(min(k, len(list)), 0)
+	if k == 0 {
+		return list[:0]
+	}
+
+	// Turn the front of the list into a min heap of the k largest elements seen so far
+	top := list[:k]
+	ds.HeapifySlice(top, lessThan[T])
+	for i := k; i < len(list); i++ {
+		if list[i] > top[0] {
+			// swap the evicted element into the larger element's slot
+			top[0], list[i] = list[i], top[0]
+			ds.FixHeapSlice(top, 0, lessThan[T])
+		}
+	}
+
+	// Popping each minimum to the end of the shrinking heap leaves the front sorted largest first
+	for end := k; end > 1; end-- {
+		ds.PopHeapSlice(top[:end], lessThan[T])
+	}
+
+	return top
+}
+
+// KSmallest selects the k smallest elements from a stream of elements of unknown length, using a
+// max heap bounded to k elements so that only O(k) memory is needed however many elements are
+// added.
+type KSmallest[T constraints.Ordered] struct {
+	k    int
+	heap *ds.MaxHeap[T]
+}
+
+// NewKSmallest returns a new selector that keeps the k smallest elements added to it.
+func NewKSmallest[T constraints.Ordered](k int) *KSmallest[T] {
+	return &KSmallest[T]{
+		k:    max(k, 0),
+		heap: ds.NewMaxHeap[T](),
+	}
+}
+
+// Len returns the number of elements currently kept, which is at most k.
+func (s KSmallest[T]) Len() int {
+	return s.heap.Size()
+}
+
+// Add offers an element to the selector in O(log(k)) time. The element is kept if fewer than k
+// elements have been added so far or if it is smaller than the largest element kept, which is then
+// discarded.
+func (s *KSmallest[T]) Add(elem T) {
+	if s.heap.Size() < s.k {
+		s.heap.Push(elem)
+	} else if top, err := s.heap.Peek(); err == nil && elem < top {
+		s.heap.Replace(elem)
+	}
+}
+
+// Max returns the largest element kept, which is the k-th smallest element added once at least k
+// elements have been added. If no elements are kept an error is returned.
+func (s KSmallest[T]) Max() (T, error) {
+	if s.heap.Size() == 0 {
+		var empty T
+		return empty, errors.New("no elements have been selected")
+	}
+
+	return s.heap.Peek()
+}
+
+// Sorted returns the elements kept in ascending order in O(k*log(k)) time. The selector can
+// continue to be used afterwards.
+func (s *KSmallest[T]) Sorted() []T {
+	sorted := make([]T, s.heap.Size())
+	// Popping the heap yields the elements largest first; fill the result from the end
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sorted[i], _ = s.heap.Pop()
+	}
+	// Rebuild the heap from a copy of the result so the selector can keep being used
+	s.heap = ds.Heapify(sorted)
+
+	return sorted
+}
+
+// lessThan orders elements from least to greatest.
+func lessThan[T constraints.Ordered](a, b T) bool {
+	return a < b
+}
+
+// greaterThan orders elements from greatest to least.
+func greaterThan[T constraints.Ordered](a, b T) bool {
+	return a > b
+}
@@ -1,6 +1,7 @@
 package sort_test
 
 import (
+	"math/rand"
 	"slices"
 	"testing"
 
@@ -126,6 +127,133 @@ func TestQuickSort(t *testing.T) {
 	runTests(t, sort.Quick, false)
 }
 
+func TestHeapSort(t *testing.T) {
+	runTests(t, sort.Heap, false)
+}
+
+func TestInPlaceHeapSort(t *testing.T) {
+	runTests(t, sort.InPlaceHeap, true)
+}
+
+func TestTopK(t *testing.T) {
+	t.Run("Should return the k largest elements, largest first", func(t *testing.T) {
+		list := []int{5, 1, 9, 3, 7, 9, 2}
+		assert.Equal(t, []int{9, 9, 7}, sort.TopK(list, 3))
+		assert.Equal(t, []int{5, 1, 9, 3, 7, 9, 2}, list, "Should not have side effects")
+	})
+
+	t.Run("Should handle k outside of the list's bounds", func(t *testing.T) {
+		list := []int{2, 3, 1}
+		assert.Equal(t, []int{3, 2, 1}, sort.TopK(list, 10))
+		assert.Equal(t, []int{}, sort.TopK(list, 0))
+		assert.Equal(t, []int{}, sort.TopK(list, -1))
+		assert.Equal(t, []int{2, 3, 1}, list, "Should not have side effects")
+	})
+
+	t.Run("Should match a full sort for random input", func(t *testing.T) {
+		r := rand.New(rand.NewSource(20))
+		list := make([]int, 1000)
+		for i := range list {
+			list[i] = r.Intn(100)
+		}
+		sorted := slices.Clone(list)
+		slices.Sort(sorted)
+		slices.Reverse(sorted)
+
+		for _, k := range []int{1, 10, 500, 1000} {
+			assert.Equal(t, sorted[:k], sort.TopK(list, k))
+		}
+	})
+}
+
+func TestInPlaceTopK(t *testing.T) {
+	t.Run("Should move the k largest elements to the front, largest first", func(t *testing.T) {
+		list := []int{5, 1, 9, 3, 7, 9, 2}
+		top := sort.InPlaceTopK(list, 3)
+		assert.Equal(t, []int{9, 9, 7}, top)
+		assert.Equal(t, []int{9, 9, 7}, list[:3], "Should have side effects")
+		assert.ElementsMatch(t, []int{5, 1, 3, 2}, list[3:])
+	})
+
+	t.Run("Should sort the whole list when k covers it", func(t *testing.T) {
+		list := []int{2, 3, 1}
+		assert.Equal(t, []int{3, 2, 1}, sort.InPlaceTopK(list, 5))
+	})
+
+	t.Run("Should leave the list untouched when k is not positive", func(t *testing.T) {
+		list := []int{5, 1, 9, 3}
+		assert.Equal(t, []int{}, sort.InPlaceTopK(list, 0))
+		assert.Equal(t, []int{5, 1, 9, 3}, list)
+		assert.Equal(t, []int{}, sort.InPlaceTopK(list, -1))
+		assert.Equal(t, []int{5, 1, 9, 3}, list)
+	})
+
+	t.Run("Should match a full sort for random input", func(t *testing.T) {
+		r := rand.New(rand.NewSource(20))
+		for _, k := range []int{1, 10, 500, 1000} {
+			list := make([]int, 1000)
+			for i := range list {
+				list[i] = r.Intn(100)
+			}
+			sorted := slices.Clone(list)
+			slices.Sort(sorted)
+			slices.Reverse(sorted)
+
+			assert.Equal(t, sorted[:k], sort.InPlaceTopK(list, k))
+			assert.ElementsMatch(t, sorted, list)
+		}
+	})
+}
+
+func TestKSmallest(t *testing.T) {
+	t.Run("Should keep the k smallest elements of a stream", func(t *testing.T) {
+		s := sort.NewKSmallest[int](3)
+		_, err := s.Max()
+		assert.NotNil(t, err)
+
+		for _, e := range []int{8, 3, 9, 1, 7, 2, 6} {
+			s.Add(e)
+			assert.LessOrEqual(t, s.Len(), 3)
+		}
+		max, err := s.Max()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, max)
+		assert.Equal(t, []int{1, 2, 3}, s.Sorted())
+
+		// the selector keeps working after Sorted
+		s.Add(0)
+		assert.Equal(t, []int{0, 1, 2}, s.Sorted())
+	})
+
+	t.Run("Should keep every element while fewer than k have been added", func(t *testing.T) {
+		s := sort.NewKSmallest[int](5)
+		s.Add(4)
+		s.Add(2)
+		assert.Equal(t, 2, s.Len())
+		assert.Equal(t, []int{2, 4}, s.Sorted())
+	})
+
+	t.Run("Should keep nothing when k is zero", func(t *testing.T) {
+		s := sort.NewKSmallest[int](0)
+		s.Add(1)
+		assert.Equal(t, 0, s.Len())
+		assert.Equal(t, []int{}, s.Sorted())
+	})
+
+	t.Run("Should match a full sort for a long random stream", func(t *testing.T) {
+		r := rand.New(rand.NewSource(20))
+		s := sort.NewKSmallest[int](50)
+		var all []int
+		for range 10_000 {
+			e := r.Int()
+			s.Add(e)
+			all = append(all, e)
+		}
+		slices.Sort(all)
+		assert.Equal(t, all[:50], s.Sorted())
+	})
+}
+
 func TestCountingSort(t *testing.T) {
 	t.Run("Should return proper sort order", func(t *testing.T) {
 		list := []uint{7, 4, 6, 5, 8, 3, 2, 0, 9, 10, 9, 1}